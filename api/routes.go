@@ -4,22 +4,39 @@ import (
 	"Backend/configs"
 	"Backend/internal/handlers/aspirations"
 	"Backend/internal/handlers/auth"
+	"Backend/internal/handlers/certificate"
 	"Backend/internal/handlers/event"
+	"Backend/internal/handlers/media"
 	"Backend/internal/handlers/news"
 	"Backend/internal/handlers/permission"
 	"Backend/internal/handlers/role"
+	"Backend/internal/handlers/search"
+	"Backend/internal/handlers/team"
 	"Backend/internal/handlers/user"
 	"Backend/internal/handlers/version"
 	"Backend/internal/middleware"
 	"Backend/internal/services"
+	"context"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes() *gin.Engine {
+// runJob starts a background job tracked by jobs
+func runJob(jobs *sync.WaitGroup, job func()) {
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		job()
+	}()
+}
+
+// SetupRoutes builds the router, background jobs run until ctx is cancelled and are tracked by jobs
+// so the caller can wait for their last flush before closing the connections they use
+func SetupRoutes(ctx context.Context, jobs *sync.WaitGroup) *gin.Engine {
 	// Set Gin to release mode for better performance
 	gin.SetMode(gin.ReleaseMode)
 	
@@ -69,13 +86,15 @@ func SetupRoutes() *gin.Engine {
 
 	authService := services.NewAuthService()
 	userService := services.NewUserService()
-	eventService := services.NewEventService()
-	newsService := services.NewNewsService()
+	teamService := services.NewTeamService()
 	roleService := services.NewRoleService()
 	permissionService := services.NewPermissionService()
 	aspirationsService := services.NewAspirationService()
+	searchService := services.NewSearchService()
 	AWSService, _ := services.NewAWSService()
 	R2Service, _ := services.NewR2Service()
+	newsService := services.NewNewsService(R2Service)
+	certificateService := services.NewCertificateService(R2Service)
 	// Get email service configuration
 	config := configs.LoadConfig()
 
@@ -147,10 +166,23 @@ func SetupRoutes() *gin.Engine {
 		)
 		log.Println("Using SendGrid email service")
 	}
+	eventService := services.NewEventService(EmailService, R2Service)
 	VersionService := services.NewVersionService(configs.LoadConfig().GithubAccessToken)
 
 	eventStatusUpdater := services.NewEventStatusUpdater(eventService)
-	go eventStatusUpdater.Run()
+	runJob(jobs, func() { eventStatusUpdater.Run(ctx) })
+
+	eventReminderService := services.NewEventReminderService(EmailService)
+	runJob(jobs, func() { eventReminderService.Run(ctx) })
+
+	newsPublisher := services.NewNewsPublisher(newsService)
+	runJob(jobs, func() { newsPublisher.Run(ctx) })
+	runJob(jobs, func() { newsService.RenderStoredContent(ctx) })
+	runJob(jobs, func() { newsService.ViewCounter.Run(ctx) })
+
+	mediaService := services.NewMediaService(R2Service)
+	mediaCollector := services.NewMediaCollector(mediaService)
+	runJob(jobs, func() { mediaCollector.Run(ctx) })
 
 	versionUpdater := services.NewVersionUpdater(VersionService)
 	go versionUpdater.Run()
@@ -158,11 +190,15 @@ func SetupRoutes() *gin.Engine {
 	authHandlers := auth.NewAuthHandlers(authService, permissionService, EmailService, userService)
 	userHandlers := user.NewUserHandlers(userService, permissionService, AWSService, R2Service)
 	eventHandlers := event.NewEventHandlers(eventService, permissionService, AWSService, R2Service)
+	teamHandlers := team.NewTeamHandlers(teamService, permissionService)
+	certificateHandlers := certificate.NewCertificateHandlers(certificateService, eventService, permissionService)
+	mediaHandlers := media.NewMediaHandlers(mediaService, permissionService)
 	newsHandlers := news.NewNewsHandler(newsService, permissionService, AWSService, R2Service)
 	roleHandlers := role.NewRoleHandler(roleService, userService, permissionService)
 	permissionHandlers := permission.NewPermissionHandler(permissionService)
 	aspirationHandlers := aspirations.NewAspirationHandlers(aspirationsService, permissionService)
 	versionHandlers := version.NewVersionHandlers(VersionService)
+	searchHandlers := search.NewSearchHandlers(searchService)
 
 	api := r.Group("/api/v1")
 
@@ -193,6 +229,18 @@ func SetupRoutes() *gin.Engine {
 
 		// ListEventsRegisteredByUser
 		userRoutes.GET("/registered-events", eventHandlers.ListEventsRegisteredByUser)
+
+		// Event reminder opt-out
+		userRoutes.GET("/event-reminders", eventHandlers.GetEventReminderPreference)
+		userRoutes.PUT("/event-reminders", eventHandlers.UpdateEventReminderPreference)
+
+		// Certificates of participation
+		userRoutes.GET("/certificates", certificateHandlers.ListMyCertificates)
+
+		// Team invitations for team-based events
+		userRoutes.GET("/team-invitations", teamHandlers.ListMyInvitations)
+		userRoutes.POST("/team-invitations/:invitationID/accept", teamHandlers.AcceptInvitation)
+		userRoutes.POST("/team-invitations/:invitationID/decline", teamHandlers.DeclineInvitation)
 	}
 
 	// Admin routes for user management
@@ -208,23 +256,110 @@ func SetupRoutes() *gin.Engine {
 		eventRoutes.GET("/:eventID", eventHandlers.GetEventBySlug)
 		eventRoutes.GET("/", eventHandlers.ListEvents)
 		eventRoutes.GET("/:eventID/total-participant", eventHandlers.TotalRegisteredUsers)
+		eventRoutes.GET("/:eventID/eligibility", eventHandlers.GetEligibility)
+		eventRoutes.GET("/:eventID/hosts", eventHandlers.GetEventHosts)
+		eventRoutes.GET("/:eventID/feedback/survey", eventHandlers.GetFeedbackSurvey)
+		eventRoutes.GET("/series/:seriesID", eventHandlers.GetEventSeries)
+		eventRoutes.GET("/:eventID/ical", eventHandlers.GetEventICalendar)
+		eventRoutes.GET("/feeds/all", eventHandlers.PublicEventsFeed)
+		eventRoutes.GET("/feeds/organization/:organizationID", eventHandlers.OrganizationEventsFeed)
+		eventRoutes.GET("/feeds/user/:token", eventHandlers.UserEventsFeed)
 		eventRoutes.Use(middleware.TokenMiddleware())
 		eventRoutes.POST("/create", eventHandlers.CreateEvent)
 		eventRoutes.PATCH("/:eventID/edit", eventHandlers.EditEvent)
 		eventRoutes.DELETE("/:eventID/delete", eventHandlers.DeleteEvent)
+		eventRoutes.POST("/:eventID/status", eventHandlers.TransitionEventStatus)
+		eventRoutes.PUT("/:eventID/hosts", eventHandlers.UpdateEventHosts)
 		eventRoutes.POST("/:eventID/register", eventHandlers.RegisterForEvent)
 		eventRoutes.GET("/:eventID/registered-users", eventHandlers.ListRegisteredUsers)
+		eventRoutes.GET("/:eventID/registered-users/export", eventHandlers.ExportRegisteredUsers)
+		eventRoutes.POST("/:eventID/registrations/:userID/check-in", eventHandlers.CheckInRegistration)
+		eventRoutes.PUT("/:eventID/eligibility", eventHandlers.UpdateEligibility)
+		eventRoutes.GET("/:eventID/eligibility/check", eventHandlers.CheckEligibility)
+
+		// Post-event feedback
+		eventRoutes.PUT("/:eventID/feedback/survey", eventHandlers.UpdateFeedbackSurvey)
+		eventRoutes.POST("/:eventID/feedback", eventHandlers.SubmitFeedback)
+		eventRoutes.GET("/:eventID/feedback/results", eventHandlers.GetFeedbackResults)
+
+		// Certificates of participation
+		eventRoutes.GET("/:eventID/certificates/template", certificateHandlers.GetTemplate)
+		eventRoutes.PUT("/:eventID/certificates/template", certificateHandlers.UpdateTemplate)
+		eventRoutes.POST("/:eventID/certificates/template/background", certificateHandlers.UploadBackground)
+		eventRoutes.POST("/:eventID/certificates/generate", certificateHandlers.GenerateCertificates)
+		eventRoutes.GET("/:eventID/certificates", certificateHandlers.ListEventCertificates)
+
+		eventRoutes.GET("/feeds/token", eventHandlers.GetCalendarFeedToken)
+		eventRoutes.POST("/feeds/token/rotate", eventHandlers.RotateCalendarFeedToken)
+
+		// Duplicating events and event templates
+		eventRoutes.POST("/:eventID/duplicate", eventHandlers.DuplicateEvent)
+		eventRoutes.POST("/:eventID/templates", eventHandlers.SaveEventTemplate)
+		eventRoutes.GET("/templates", eventHandlers.ListEventTemplates)
+		eventRoutes.GET("/templates/:templateID", eventHandlers.GetEventTemplate)
+		eventRoutes.POST("/templates/:templateID/create", eventHandlers.CreateEventFromTemplate)
+		eventRoutes.DELETE("/templates/:templateID/delete", eventHandlers.DeleteEventTemplate)
+
+		// Recurring events
+		eventRoutes.POST("/series/create", eventHandlers.CreateEventSeries)
+		eventRoutes.POST("/series/:seriesID/exceptions", eventHandlers.AddSeriesException)
+		eventRoutes.DELETE("/series/:seriesID/delete", eventHandlers.DeleteEventSeries)
+
+		// Team registration
+		eventRoutes.POST("/:eventID/teams", teamHandlers.CreateTeam)
+		eventRoutes.GET("/:eventID/teams", teamHandlers.ListTeams)
+		eventRoutes.GET("/:eventID/my-team", teamHandlers.GetMyTeam)
+		eventRoutes.POST("/:eventID/teams/:teamID/invite", teamHandlers.InviteMember)
+		eventRoutes.POST("/:eventID/teams/:teamID/register", teamHandlers.RegisterTeam)
+		eventRoutes.DELETE("/:eventID/teams/:teamID/members/:userID", teamHandlers.RemoveMember)
+		eventRoutes.DELETE("/:eventID/teams/:teamID/delete", teamHandlers.DisbandTeam)
 	}
 
 	newsRoutes := api.Group("/news")
 	{
 		newsRoutes.GET("/", newsHandlers.ListNews)
 		newsRoutes.GET("/:newsID", newsHandlers.GetNewsBySlug)
+		newsRoutes.GET("/tags", newsHandlers.ListNewsTags)
+		newsRoutes.GET("/tags/cloud", newsHandlers.NewsTagCloud)
+		newsRoutes.GET("/trending", newsHandlers.ListTrendingNews)
+		newsRoutes.GET("/:newsID/comments", newsHandlers.ListNewsComments)
+		newsRoutes.GET("/feeds/rss", newsHandlers.NewsRSSFeed)
+		newsRoutes.GET("/feeds/atom", newsHandlers.NewsAtomFeed)
+		newsRoutes.GET("/feeds/organization/:organizationID/rss", newsHandlers.OrganizationNewsRSSFeed)
+		newsRoutes.GET("/feeds/organization/:organizationID/atom", newsHandlers.OrganizationNewsAtomFeed)
 		newsRoutes.Use(middleware.TokenMiddleware())
 		newsRoutes.POST("/create", newsHandlers.CreateNews)
 		newsRoutes.PUT("/:newsID/edit", newsHandlers.EditNews)
+		newsRoutes.PUT("/:newsID/status", newsHandlers.UpdateNewsStatus)
+		newsRoutes.GET("/:newsID/preview", newsHandlers.PreviewNews)
 		newsRoutes.DELETE("/:newsID/delete", newsHandlers.DeleteNews)
 		newsRoutes.POST("/:newsID/like", newsHandlers.LikeNews)
+		newsRoutes.DELETE("/:newsID/like", newsHandlers.UnlikeNews)
+		newsRoutes.POST("/:newsID/comments", newsHandlers.CreateNewsComment)
+		newsRoutes.GET("/comments/reports", newsHandlers.ListReportedNewsComments)
+		newsRoutes.PUT("/comments/:commentID", newsHandlers.EditNewsComment)
+		newsRoutes.DELETE("/comments/:commentID", newsHandlers.DeleteNewsComment)
+		newsRoutes.PUT("/comments/:commentID/visibility", newsHandlers.SetNewsCommentVisibility)
+		newsRoutes.POST("/comments/:commentID/report", newsHandlers.ReportNewsComment)
+		newsRoutes.DELETE("/comments/:commentID/reports", newsHandlers.DismissNewsCommentReports)
+		newsRoutes.POST("/tags", newsHandlers.CreateNewsTag)
+		newsRoutes.PUT("/tags/:tagID", newsHandlers.UpdateNewsTag)
+		newsRoutes.DELETE("/tags/:tagID", newsHandlers.DeleteNewsTag)
+		newsRoutes.PUT("/:newsID/tags", newsHandlers.SetNewsTags)
+		newsRoutes.GET("/:newsID/revisions", newsHandlers.ListNewsRevisions)
+		newsRoutes.GET("/:newsID/revisions/diff", newsHandlers.DiffNewsRevisions)
+		newsRoutes.GET("/:newsID/revisions/:revision", newsHandlers.GetNewsRevision)
+		newsRoutes.POST("/:newsID/revisions/:revision/restore", newsHandlers.RestoreNewsRevision)
+	}
+
+	// Full-text search over news, events and aspirations
+	api.GET("/search", searchHandlers.Search)
+
+	// Images embedded in the content of news and events
+	mediaRoutes := api.Group("/media")
+	{
+		mediaRoutes.Use(middleware.TokenMiddleware())
+		mediaRoutes.POST("/upload", mediaHandlers.UploadMedia)
 	}
 
 	roleRoutes := api.Group("/roles")
@@ -258,6 +393,11 @@ func SetupRoutes() *gin.Engine {
 		aspirationRoutes.POST("/:id/admin_reply", aspirationHandlers.AddAdminReply)
 	}
 
+	certificateRoutes := api.Group("/certificates")
+	{
+		certificateRoutes.GET("/verify/:code", certificateHandlers.VerifyCertificate)
+	}
+
 	versionRoutes := api.Group("/version")
 	{
 		versionRoutes.GET("/", versionHandlers.GetVersion)
@@ -5,13 +5,22 @@ import (
 	"Backend/configs"
 	"Backend/internal/database"
 	"Backend/pkg/utils"
+	"context"
 	"github.com/joho/godotenv"
 	"log"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
+
+	// Events carry IANA timezones and the runtime image ships without tzdata
+	_ "time/tzdata"
 )
 
+// jobShutdownTimeout bounds how long shutdown waits for background jobs to finish their last run
+const jobShutdownTimeout = 15 * time.Second
+
 func tryInitRedis() {
 	defer func() {
 		if r := recover(); r != nil {
@@ -36,7 +45,12 @@ func main() {
 	// Try to initialize Redis, but continue if it fails
 	tryInitRedis()
 
-	r := api.SetupRoutes()
+	// Cancelled on shutdown to stop background jobs
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var jobs sync.WaitGroup
+	r := api.SetupRoutes(ctx, &jobs)
 
 	// Setup graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -57,6 +71,19 @@ func main() {
 			log.Fatalf("Failed to run server: %v", err)
 		case <-quit:
 			log.Println("Server is shutting down...")
+
+			// Stop background jobs and let them flush before their connections are closed
+			cancel()
+			jobsDone := make(chan struct{})
+			go func() {
+				jobs.Wait()
+				close(jobsDone)
+			}()
+			select {
+			case <-jobsDone:
+			case <-time.After(jobShutdownTimeout):
+				log.Println("Background jobs did not stop in time")
+			}
 			
 			// Close Redis connection
 			log.Println("Closing Redis connection...")
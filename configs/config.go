@@ -48,6 +48,9 @@ type Config struct {
 	HunterApiKey      string
 
 	BaseURL string
+
+	// Durations before the start of an event at which registrants are reminded, such as "24h,1h"
+	EventReminders string
 }
 
 func LoadConfig() *Config {
@@ -100,6 +103,7 @@ func LoadConfig() *Config {
         BaseURL:               baseURl,
         GithubAccessToken:     os.Getenv("GH_ACCESS_TOKEN"),
         HunterApiKey:          os.Getenv("HUNTER_API_KEY"),
+        EventReminders:        os.Getenv("EVENT_REMINDERS"),
     }
 
     fmt.Printf("Loaded Config: %+v\n", cfg)
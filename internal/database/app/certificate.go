@@ -0,0 +1,156 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetCertificateTemplate retrieves the certificate template of an event
+func GetCertificateTemplate(eventID int) (*models.CertificateTemplate, error) {
+	template := models.CertificateTemplate{EventID: eventID}
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT background_url, orientation, audience, fields, updated_at
+		FROM certificate_templates WHERE event_id = $1`, eventID).Scan(
+		&template.BackgroundURL, &template.Orientation, &template.Audience, &template.Fields, &template.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "This event has no certificate template"}
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// UpsertCertificateTemplate creates or replaces the layout of a certificate template, its background is kept
+func UpsertCertificateTemplate(template *models.CertificateTemplate) error {
+	return database.DB.QueryRow(context.Background(), `
+		INSERT INTO certificate_templates (event_id, orientation, audience, fields)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id) DO UPDATE SET
+			orientation = EXCLUDED.orientation,
+			audience = EXCLUDED.audience,
+			fields = EXCLUDED.fields,
+			updated_at = NOW()
+		RETURNING background_url, updated_at`,
+		template.EventID, template.Orientation, template.Audience, template.Fields).Scan(&template.BackgroundURL, &template.UpdatedAt)
+}
+
+// SetCertificateBackground sets the background image of a certificate template, creating the template if needed
+func SetCertificateBackground(eventID int, backgroundURL string) error {
+	_, err := database.DB.Exec(context.Background(), `
+		INSERT INTO certificate_templates (event_id, background_url)
+		VALUES ($1, $2)
+		ON CONFLICT (event_id) DO UPDATE SET
+			background_url = EXCLUDED.background_url,
+			updated_at = NOW()`, eventID, backgroundURL)
+	return err
+}
+
+// ListCertificateRecipients retrieves the registrants of an event who have no certificate yet
+func ListCertificateRecipients(eventID int, checkedInOnly bool) ([]*models.CertificateRecipient, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT u.id, CONCAT(u.first_name, ' ', u.last_name)
+		FROM event_registrations er
+		JOIN users u ON u.id = er.user_id
+		LEFT JOIN certificates c ON c.event_id = er.event_id AND c.user_id = er.user_id
+		WHERE er.event_id = $1 AND c.id IS NULL AND ($2 = FALSE OR er.checked_in_at IS NOT NULL)
+		ORDER BY u.first_name, u.last_name`, eventID, checkedInOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []*models.CertificateRecipient
+	for rows.Next() {
+		var recipient models.CertificateRecipient
+		if err := rows.Scan(&recipient.UserID, &recipient.Name); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, &recipient)
+	}
+
+	return recipients, rows.Err()
+}
+
+// CreateCertificate stores a generated certificate, a user gets one certificate per event
+func CreateCertificate(certificate *models.Certificate) error {
+	err := database.DB.QueryRow(context.Background(), `
+		INSERT INTO certificates (code, event_id, user_id, recipient_name, file_url)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (event_id, user_id) DO NOTHING
+		RETURNING id, issued_at`,
+		certificate.Code, certificate.EventID, certificate.UserID, certificate.RecipientName, certificate.FileURL).Scan(
+		&certificate.ID, &certificate.IssuedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &utils.ConflictError{Message: "A certificate was already issued to this user"}
+		}
+		return err
+	}
+
+	return nil
+}
+
+const certificateSelect = `
+	SELECT c.id, c.code, c.event_id, c.user_id, c.recipient_name, c.file_url, c.issued_at, e.title, e.start_date, COALESCE(o.name, '')
+	FROM certificates c
+	JOIN events e ON e.id = c.event_id
+	LEFT JOIN organizations o ON o.id = e.organization_id`
+
+func scanCertificate(row pgx.Row) (*models.Certificate, error) {
+	var certificate models.Certificate
+	err := row.Scan(&certificate.ID, &certificate.Code, &certificate.EventID, &certificate.UserID, &certificate.RecipientName,
+		&certificate.FileURL, &certificate.IssuedAt, &certificate.EventTitle, &certificate.EventStartDate, &certificate.Organization)
+	if err != nil {
+		return nil, err
+	}
+	return &certificate, nil
+}
+
+// GetCertificateByCode retrieves a certificate by its verification code
+func GetCertificateByCode(code string) (*models.Certificate, error) {
+	certificate, err := scanCertificate(database.DB.QueryRow(context.Background(), certificateSelect+` WHERE c.code = $1`, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Certificate not found"}
+		}
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+// ListCertificatesByEvent retrieves the certificates issued for an event
+func ListCertificatesByEvent(eventID int) ([]*models.Certificate, error) {
+	return listCertificates(certificateSelect+` WHERE c.event_id = $1 ORDER BY c.recipient_name`, eventID)
+}
+
+// ListCertificatesByUser retrieves the certificates issued to a user
+func ListCertificatesByUser(userID uuid.UUID) ([]*models.Certificate, error) {
+	return listCertificates(certificateSelect+` WHERE c.user_id = $1 ORDER BY e.start_date DESC`, userID)
+}
+
+func listCertificates(query string, arg interface{}) ([]*models.Certificate, error) {
+	rows, err := database.DB.Query(context.Background(), query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	certificates := []*models.Certificate{}
+	for rows.Next() {
+		certificate, err := scanCertificate(rows)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, rows.Err()
+}
@@ -9,7 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
-	"strconv"
+	"github.com/jackc/pgx/v5"
+	"strings"
 	"time"
 )
 
@@ -17,17 +18,16 @@ type Event struct {
 }
 
 func CreateEvent(event *models.Event) error {
-	_, err := database.DB.Exec(context.Background(), `
-        INSERT INTO events (title, description, start_date, end_date, user_id, status, slug, thumbnail, organization_id, max_registration) 
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
-		event.Title, event.Description, event.StartDate, event.EndDate, event.UserID, event.Status, event.Slug, event.Thumbnail, event.OrganizationID, event.MaxRegistration)
-	return err
+	err := database.DB.QueryRow(context.Background(), `
+        INSERT INTO events (title, description, start_date, end_date, user_id, status, slug, thumbnail, organization_id, max_registration, team_registration, min_team_size, max_team_size, open_for_all, timezone, venue, online_meeting_url, all_day) 
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+        RETURNING id`,
+		event.Title, event.Description, event.StartDate, event.EndDate, event.UserID, event.Status, event.Slug, event.Thumbnail, event.OrganizationID, event.MaxRegistration, event.TeamRegistration, event.MinTeamSize, event.MaxTeamSize, event.OpenForAll, event.Timezone, event.Venue, event.OnlineMeetingURL, event.AllDay).Scan(&event.ID)
+	return slugConflict(err)
 }
 
-// UpdateEvent updates an existing event record in the database with partial data
-func UpdateEvent(eventID int, updatedEvent *models.Event) error {
-	// Use a simpler approach with a direct update query
-	query := `UPDATE events SET 
+// updateEventQuery updates every editable column of an event
+const updateEventQuery = `UPDATE events SET 
 		title = $1, 
 		description = $2, 
 		start_date = $3, 
@@ -37,16 +37,20 @@ func UpdateEvent(eventID int, updatedEvent *models.Event) error {
 		thumbnail = $7, 
 		organization_id = $8, 
 		max_registration = $9,
-		updated_at = $10
-		WHERE id = $11`
-
-	// Log the query and parameters for debugging
-	fmt.Printf("Updating event %d with data: %+v\n", eventID, updatedEvent)
-
-	// Execute the update query
-	_, err := database.DB.Exec(
-		context.Background(),
-		query,
+		team_registration = $10,
+		min_team_size = $11,
+		max_team_size = $12,
+		open_for_all = $13,
+		timezone = $14,
+		venue = $15,
+		online_meeting_url = $16,
+		all_day = $17,
+		updated_at = $18
+		WHERE id = $19`
+
+// updateEventArgs returns the parameters of updateEventQuery for an event
+func updateEventArgs(eventID int, updatedEvent *models.Event) []interface{} {
+	return []interface{}{
 		updatedEvent.Title,
 		updatedEvent.Description,
 		updatedEvent.StartDate,
@@ -56,13 +60,30 @@ func UpdateEvent(eventID int, updatedEvent *models.Event) error {
 		updatedEvent.Thumbnail,
 		updatedEvent.OrganizationID,
 		updatedEvent.MaxRegistration,
+		updatedEvent.TeamRegistration,
+		updatedEvent.MinTeamSize,
+		updatedEvent.MaxTeamSize,
+		updatedEvent.OpenForAll,
+		updatedEvent.Timezone,
+		updatedEvent.Venue,
+		updatedEvent.OnlineMeetingURL,
+		updatedEvent.AllDay,
 		time.Now(), // updated_at
 		eventID,
-	)
+	}
+}
+
+// UpdateEvent updates an existing event record in the database with partial data
+func UpdateEvent(eventID int, updatedEvent *models.Event) error {
+	// Log the query and parameters for debugging
+	fmt.Printf("Updating event %d with data: %+v\n", eventID, updatedEvent)
+
+	// Execute the update query
+	_, err := database.DB.Exec(context.Background(), updateEventQuery, updateEventArgs(eventID, updatedEvent)...)
 
 	if err != nil {
 		fmt.Printf("Error updating event: %v\n", err)
-		return err
+		return slugConflict(err)
 	}
 
 	fmt.Printf("Successfully updated event %d\n", eventID)
@@ -86,14 +107,14 @@ func DeleteEvent(eventID int) error {
 func GetEventByID(eventID int) (*models.Event, error) {
 	var event models.Event
 	err := database.DB.QueryRow(context.Background(), `
-		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, o.name as organization, CONCAT(u.first_name, ' ', u.last_name) AS author, COUNT(er.user_id) as total_registered
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.all_day, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, e.team_registration, e.min_team_size, e.max_team_size, e.open_for_all, e.series_id, e.timezone, e.venue, e.online_meeting_url, o.name as organization, CONCAT(u.first_name, ' ', u.last_name) AS author, COUNT(er.user_id) as total_registered
 		FROM events e
 		LEFT JOIN organizations o ON e.organization_id = o.id
 		LEFT JOIN users u ON e.user_id = u.id
 		LEFT JOIN event_registrations er ON e.id = er.event_id
 		WHERE e.id = $1
 		GROUP BY e.id, o.name, u.first_name, u.last_name`, eventID).Scan(
-		&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.Organization, &event.Author, &event.TotalRegistered)
+		&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.AllDay, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.TeamRegistration, &event.MinTeamSize, &event.MaxTeamSize, &event.OpenForAll, &event.SeriesID, &event.Timezone, &event.Venue, &event.OnlineMeetingURL, &event.Organization, &event.Author, &event.TotalRegistered)
 	if err != nil {
 		return nil, err
 	}
@@ -104,83 +125,163 @@ func GetEventByID(eventID int) (*models.Event, error) {
 func GetEventBySlug(slug string) (*models.Event, error) {
 	var event models.Event
 	err := database.DB.QueryRow(context.Background(), `
-		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, o.name as organization, CONCAT(u.first_name, ' ', u.last_name) AS author, COUNT(er.user_id) as total_registered
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.all_day, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, e.team_registration, e.min_team_size, e.max_team_size, e.open_for_all, e.series_id, e.timezone, e.venue, e.online_meeting_url, o.name as organization, CONCAT(u.first_name, ' ', u.last_name) AS author, COUNT(er.user_id) as total_registered
 		FROM events e
 		LEFT JOIN organizations o ON e.organization_id = o.id
 		LEFT JOIN users u ON e.user_id = u.id
 		LEFT JOIN event_registrations er ON e.id = er.event_id
 		WHERE e.slug = $1
 		GROUP BY e.id, o.name, u.first_name, u.last_name`, slug).Scan(
-		&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.Organization, &event.Author, &event.TotalRegistered)
+		&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.AllDay, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.TeamRegistration, &event.MinTeamSize, &event.MaxTeamSize, &event.OpenForAll, &event.SeriesID, &event.Timezone, &event.Venue, &event.OnlineMeetingURL, &event.Organization, &event.Author, &event.TotalRegistered)
 	if err != nil {
 		return nil, err
 	}
 	return &event, nil
 }
 
-// ListEvents returns a list of events based on the query parameters
-func ListEvents(queryParams map[string]string) ([]*models.Event, int, error) {
-	// Limit return data to 10 records per page
-	limit := 10
+// eventRegistrationCounts counts the registrants of an event and the slots they take,
+// a team takes a single slot the same way as in countRegistrationSlots
+const eventRegistrationCounts = `LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS registered, COUNT(DISTINCT er.team_id) + COUNT(*) FILTER (WHERE er.team_id IS NULL) AS slots
+			FROM event_registrations er WHERE er.event_id = e.id
+		) r ON TRUE`
+
+// eventSortColumns are the columns events can be sorted by, with the type their cursor values are cast to
+var eventSortColumns = map[string][2]string{
+	models.EventSortCreatedAt: {"e.created_at", "timestamp"},
+	models.EventSortStartDate: {"e.start_date", "timestamptz"},
+	models.EventSortTitle:     {"e.title", "text"},
+}
 
-	// Build the query
-	query := `
-		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, o.name AS organization, CONCAT(u.first_name, ' ', u.last_name) AS author
-		FROM events e
-		LEFT JOIN organizations o ON e.organization_id = o.id
-		LEFT JOIN users u ON e.user_id = u.id
-		WHERE 1 = 1`
+// eventListQuery collects the conditions of a list of events along with their arguments
+type eventListQuery struct {
+	conditions []string
+	args       []interface{}
+}
 
-	// Add query parameters to the query
-	if queryParams["organization_id"] != "" {
-		query += " AND o.id = " + queryParams["organization_id"]
+// arg adds an argument and returns its placeholder
+func (q *eventListQuery) arg(value interface{}) string {
+	q.args = append(q.args, value)
+	return fmt.Sprintf("$%d", len(q.args))
+}
+
+func (q *eventListQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+func (q *eventListQuery) whereClause() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// ListEvents returns the events matching a filter along with the number of matching events
+func ListEvents(filter *models.EventListFilter) ([]*models.Event, int, error) {
+	q := &eventListQuery{}
+	rank := ""
+
+	if filter.Search != "" {
+		query := "websearch_to_tsquery('simple', " + q.arg(filter.Search) + ")"
+		// The same vector and ranking as the site wide search, so both order events alike
+		q.where("e.search_vector @@ " + query)
+		rank = "ts_rank_cd(e.search_vector, " + query + ")"
+	}
+
+	// Organizations list the events they co-host along with their own
+	if filter.OrganizationID != nil {
+		q.where("EXISTS (SELECT 1 FROM event_hosts h WHERE h.event_id = e.id AND h.organization_id = " + q.arg(*filter.OrganizationID) + ")")
+	}
+
+	// The list is public, drafts are never part of it
+	q.where("e.status <> 'draft'")
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		q.where("e.status = ANY(" + q.arg(statuses) + ")")
 	}
 
-	if queryParams["status"] != "" {
-		query += " AND e.status = '" + queryParams["status"] + "'"
+	// Date filters match the calendar days of each event in its own timezone
+	if filter.From != nil {
+		q.where("(e.end_date AT TIME ZONE e.timezone)::date >= " + q.arg(filter.From.Format("2006-01-02")) + "::date")
+	}
+	if filter.To != nil {
+		q.where("(e.start_date AT TIME ZONE e.timezone)::date <= " + q.arg(filter.To.Format("2006-01-02")) + "::date")
+	}
+
+	if filter.OpenForAll != nil {
+		q.where("e.open_for_all = " + q.arg(*filter.OpenForAll))
+	}
+
+	if filter.HasCapacity != nil {
+		if *filter.HasCapacity {
+			q.where("(e.max_registration IS NULL OR r.slots < e.max_registration)")
+		} else {
+			q.where("e.max_registration IS NOT NULL AND r.slots >= e.max_registration")
+		}
 	}
 
-	var totalRecords int
-	err := database.DB.QueryRow(context.Background(), "SELECT COUNT(*) FROM events").Scan(&totalRecords)
+	// The total ignores the cursor so every page reports the same number of matching events
+	var total int
+	err := database.DB.QueryRow(context.Background(),
+		"SELECT COUNT(*) FROM events e "+eventRegistrationCounts+q.whereClause(), q.args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	totalPages := (totalRecords + limit - 1) / limit
+	direction, comparison := "ASC", ">"
+	if filter.Descending {
+		direction, comparison = "DESC", "<"
+	}
 
-	if queryParams["page"] != "" {
-		page, err := strconv.Atoi(queryParams["page"])
-		if err != nil {
-			return nil, totalPages, err
+	var orderBy string
+	if filter.Sort == models.EventSortRelevance && rank != "" {
+		orderBy = rank + " DESC, e.id DESC"
+	} else {
+		column, ok := eventSortColumns[filter.Sort]
+		if !ok {
+			column = eventSortColumns[models.EventSortCreatedAt]
+		}
+		orderBy = fmt.Sprintf("%s %s, e.id %s", column[0], direction, direction)
+
+		if filter.AfterValue != nil {
+			q.where(fmt.Sprintf("(%s, e.id) %s (%s::text::%s, %s)",
+				column[0], comparison, q.arg(*filter.AfterValue), column[1], q.arg(filter.AfterID)))
 		}
-		offset := (page - 1) * limit
-		query += fmt.Sprintf(" ORDER BY e.created_at DESC LIMIT %d OFFSET %d", limit, offset)
 	}
 
-	// Execute the query
-	rows, err := database.DB.Query(context.Background(), query)
+	query := `
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.all_day, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, e.team_registration, e.min_team_size, e.max_team_size, e.open_for_all, e.series_id, e.timezone, e.venue, e.online_meeting_url, o.name AS organization, CONCAT(u.first_name, ' ', u.last_name) AS author, r.registered
+		FROM events e
+		LEFT JOIN organizations o ON e.organization_id = o.id
+		LEFT JOIN users u ON e.user_id = u.id
+		` + eventRegistrationCounts + q.whereClause() +
+		fmt.Sprintf(" ORDER BY %s LIMIT %s OFFSET %s", orderBy, q.arg(filter.Limit), q.arg(filter.Offset))
+
+	rows, err := database.DB.Query(context.Background(), query, q.args...)
 	if err != nil {
-		return nil, totalPages, err
+		return nil, total, err
 	}
-
 	defer rows.Close()
 
-	var events []*models.Event
+	events := []*models.Event{}
 	for rows.Next() {
 		var event models.Event
 		err := rows.Scan(
-			&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.Organization, &event.Author)
+			&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.AllDay, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.TeamRegistration, &event.MinTeamSize, &event.MaxTeamSize, &event.OpenForAll, &event.SeriesID, &event.Timezone, &event.Venue, &event.OnlineMeetingURL, &event.Organization, &event.Author, &event.TotalRegistered)
 		if err != nil {
-			return nil, totalPages, err
+			return nil, total, err
 		}
 		events = append(events, &event)
 	}
 
-	return events, totalPages, nil
+	return events, total, rows.Err()
 }
 
 // RegisterForEvent registers a user for an event by creating a new event registration record
-func RegisterForEvent(userID uuid.UUID, eventID int, additionalNotes string) error {
+func RegisterForEvent(userID uuid.UUID, eventID int, additionalNotes string, formAnswers map[string]string) error {
 	tx, err := database.DB.Begin(context.Background())
 	if err != nil {
 		return err
@@ -207,25 +308,29 @@ func RegisterForEvent(userID uuid.UUID, eventID int, additionalNotes string) err
 
 	// Check if the event has a maximum registration limit
 	var maxRegistration *int
+	var teamRegistration bool
 	err = tx.QueryRow(context.Background(), `
-        SELECT max_registration FROM events WHERE id = $1`, eventID).Scan(&maxRegistration)
+        SELECT max_registration, team_registration FROM events WHERE id = $1`, eventID).Scan(&maxRegistration, &teamRegistration)
 	if err != nil {
 		// Check if the error is due to no rows being returned
 		if errors.Is(err, sql.ErrNoRows) {
 			// No registration limit specified for the event, proceed with registration
 			_, err = tx.Exec(context.Background(), `
-                INSERT INTO event_registrations (event_id, user_id, registration_date, additional_notes)
-                VALUES ($1, $2, $3, $4)`, eventID, userID, time.Now(), additionalNotes)
+                INSERT INTO event_registrations (event_id, user_id, registration_date, additional_notes, form_answers)
+                VALUES ($1, $2, $3, $4, $5)`, eventID, userID, time.Now(), additionalNotes, formAnswers)
 			return err
 		}
 		return err
 	}
 
+	// Team events are registered through their team captain
+	if teamRegistration {
+		return utils.TeamRegistrationRequiredError{EventID: eventID}
+	}
+
 	if maxRegistration != nil && *maxRegistration > 0 {
 		// Check if the maximum registration limit has been reached
-		var count int
-		err := database.DB.QueryRow(context.Background(), `
-            SELECT COUNT(*) FROM event_registrations WHERE event_id = $1`, eventID).Scan(&count)
+		count, err := countRegistrationSlots(tx, eventID)
 		if err != nil {
 			return err
 		}
@@ -248,8 +353,8 @@ func RegisterForEvent(userID uuid.UUID, eventID int, additionalNotes string) err
 	}
 
 	_, err = database.DB.Exec(context.Background(), `
-        INSERT INTO event_registrations (event_id, user_id, registration_date, additional_notes)
-        VALUES ($1, $2, $3, $4)`, eventID, userID, time.Now(), additionalNotes)
+        INSERT INTO event_registrations (event_id, user_id, registration_date, additional_notes, form_answers)
+        VALUES ($1, $2, $3, $4, $5)`, eventID, userID, time.Now(), additionalNotes, formAnswers)
 	return err
 }
 
@@ -257,9 +362,10 @@ func RegisterForEvent(userID uuid.UUID, eventID int, additionalNotes string) err
 func ListRegisteredUsers(eventID int) ([]*models.User, error) {
 	rows, err := database.DB.Query(context.Background(), `
         SELECT u.id, u.username, u.first_name, u.last_name, u.email, u.student_id, u.major, u.profile_picture, u.date_of_birth, u.role_id, u.created_at, u.updated_at, u.year, u.institution_name,
-               er.additional_notes
+               er.additional_notes, t.name AS team_name
         FROM users u
         JOIN event_registrations er ON u.id = er.user_id
+        LEFT JOIN event_teams t ON er.team_id = t.id
         WHERE er.event_id = $1`, eventID)
 	if err != nil {
 		return nil, err
@@ -271,7 +377,7 @@ func ListRegisteredUsers(eventID int) ([]*models.User, error) {
 		var registration models.User
 		err := rows.Scan(
 			&registration.ID, &registration.Username, &registration.FirstName, &registration.LastName, &registration.Email, &registration.StudentID, &registration.Major, &registration.ProfilePicture, &registration.DateOfBirth, &registration.RoleID, &registration.CreatedAt, &registration.UpdatedAt, &registration.Year, &registration.InstitutionName,
-			&registration.AdditionalNotes,
+			&registration.AdditionalNotes, &registration.TeamName,
 		)
 		if err != nil {
 			return nil, err
@@ -284,7 +390,7 @@ func ListRegisteredUsers(eventID int) ([]*models.User, error) {
 
 func ListEventsRegisteredByUser(userID uuid.UUID) ([]*models.Event, error) {
 	rows, err := database.DB.Query(context.Background(), `
-		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, o.name as organization_name
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.all_day, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, e.team_registration, e.min_team_size, e.max_team_size, e.open_for_all, e.series_id, e.timezone, e.venue, e.online_meeting_url, o.name as organization_name
 		FROM events e
 		JOIN event_registrations er ON e.id = er.event_id
 		JOIN organizations o ON e.organization_id = o.id
@@ -299,7 +405,7 @@ func ListEventsRegisteredByUser(userID uuid.UUID) ([]*models.Event, error) {
 	for rows.Next() {
 		var event models.Event
 		err := rows.Scan(
-			&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.Organization)
+			&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.AllDay, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.TeamRegistration, &event.MinTeamSize, &event.MaxTeamSize, &event.OpenForAll, &event.SeriesID, &event.Timezone, &event.Venue, &event.OnlineMeetingURL, &event.Organization)
 		if err != nil {
 			return nil, err
 		}
@@ -318,3 +424,90 @@ func TotalRegisteredUsers(eventID int) (int, error) {
 	}
 	return totalRegistered, nil
 }
+
+// CheckInRegistration marks a registered user as checked in, checking in twice keeps the first check-in time
+func CheckInRegistration(eventID int, userID uuid.UUID) (*models.EventRegistration, error) {
+	var registration models.EventRegistration
+	err := database.DB.QueryRow(context.Background(), `
+		UPDATE event_registrations SET checked_in_at = COALESCE(checked_in_at, NOW())
+		WHERE event_id = $1 AND user_id = $2
+		RETURNING id, event_id, user_id, registration_date, COALESCE(additional_notes, ''), team_id, form_answers, checked_in_at`,
+		eventID, userID).Scan(
+		&registration.ID, &registration.EventID, &registration.UserID, &registration.RegistrationDate,
+		&registration.AdditionalNotes, &registration.TeamID, &registration.FormAnswers, &registration.CheckedInAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "User is not registered for this event"}
+		}
+		return nil, err
+	}
+
+	return &registration, nil
+}
+
+// ListFormAnswerKeys returns the questions answered in the registrations of an event
+func ListFormAnswerKeys(eventID int) ([]string, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT DISTINCT jsonb_object_keys(form_answers) AS question
+		FROM event_registrations WHERE event_id = $1
+		ORDER BY question`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// StreamRegistrationsForExport calls fn for every registrant of an event without loading them all into memory
+func StreamRegistrationsForExport(ctx context.Context, eventID int, fn func(row *models.RegistrationExportRow) error) error {
+	rows, err := database.DB.Query(ctx, `
+		SELECT u.first_name, u.last_name, u.student_id, u.major, u.year, u.email, er.additional_notes, t.name, er.form_answers, er.checked_in_at
+		FROM event_registrations er
+		JOIN users u ON er.user_id = u.id
+		LEFT JOIN event_teams t ON er.team_id = t.id
+		WHERE er.event_id = $1
+		ORDER BY er.registration_date, er.id`, eventID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.RegistrationExportRow
+		err := rows.Scan(&row.FirstName, &row.LastName, &row.StudentID, &row.Major, &row.Year, &row.Email,
+			&row.Notes, &row.TeamName, &row.FormAnswers, &row.CheckedInAt)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(&row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// UpdateEventStatus changes the status of an event only if it still has the expected status
+func UpdateEventStatus(eventID int, from, to models.EventStatus) error {
+	tag, err := database.DB.Exec(context.Background(), `
+		UPDATE events SET status = $1, updated_at = NOW() WHERE id = $2 AND status = $3`, to, eventID, from)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return &utils.ConflictError{Message: "The event status was changed in the meantime, please try again"}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// ListCalendarEvents retrieves events ending after since, organizationID 0 returns events of every organization
+// and any other organizationID the events it hosts or co-hosts
+func ListCalendarEvents(organizationID int, since time.Time) ([]*models.Event, error) {
+	query := `
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.all_day, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, e.team_registration, e.min_team_size, e.max_team_size, e.open_for_all, e.series_id, e.timezone, e.venue, e.online_meeting_url, o.name AS organization
+		FROM events e
+		LEFT JOIN organizations o ON e.organization_id = o.id
+		WHERE e.end_date >= $1 AND e.status <> 'draft'`
+	args := []interface{}{since}
+
+	if organizationID != 0 {
+		query += ` AND EXISTS (SELECT 1 FROM event_hosts h WHERE h.event_id = e.id AND h.organization_id = $2)`
+		args = append(args, organizationID)
+	}
+	query += ` ORDER BY e.start_date`
+
+	rows, err := database.DB.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		var event models.Event
+		err := rows.Scan(
+			&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.AllDay, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.TeamRegistration, &event.MinTeamSize, &event.MaxTeamSize, &event.OpenForAll, &event.SeriesID, &event.Timezone, &event.Venue, &event.OnlineMeetingURL, &event.Organization)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// GetCalendarToken retrieves the calendar feed token of a user, it is nil when no token was created yet
+func GetCalendarToken(userID uuid.UUID) (*string, error) {
+	var token *string
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT calendar_token FROM users WHERE id = $1`, userID).Scan(&token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "User not found"}
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// SetCalendarToken replaces the calendar feed token of a user
+func SetCalendarToken(userID uuid.UUID, token string) error {
+	_, err := database.DB.Exec(context.Background(), `
+		UPDATE users SET calendar_token = $1 WHERE id = $2`, token, userID)
+	return err
+}
+
+// GetUserIDByCalendarToken finds the owner of a calendar feed token
+func GetUserIDByCalendarToken(token string) (uuid.UUID, error) {
+	var userID uuid.UUID
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT id FROM users WHERE calendar_token = $1`, token).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, &utils.NotFoundError{Message: "Calendar feed not found"}
+		}
+		return uuid.Nil, err
+	}
+
+	return userID, nil
+}
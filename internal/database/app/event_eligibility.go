@@ -0,0 +1,64 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetEventEligibility retrieves the eligibility rules of an event, events without rules get empty rules
+func GetEventEligibility(eventID int) (*models.EventEligibility, error) {
+	eligibility := models.EventEligibility{
+		EventID:        eventID,
+		AllowedMajors:  []string{},
+		AllowedYears:   []string{},
+		AllowedRoleIDs: []int{},
+	}
+
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT allowed_majors, allowed_years, allowed_role_ids, require_student_id_verified, organization_members_only, updated_at
+		FROM event_eligibility_rules WHERE event_id = $1`, eventID).Scan(
+		&eligibility.AllowedMajors, &eligibility.AllowedYears, &eligibility.AllowedRoleIDs,
+		&eligibility.RequireStudentIDVerified, &eligibility.OrganizationMembersOnly, &eligibility.UpdatedAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	return &eligibility, nil
+}
+
+// UpsertEventEligibility creates or replaces the eligibility rules of an event
+func UpsertEventEligibility(eligibility *models.EventEligibility) error {
+	return database.DB.QueryRow(context.Background(), `
+		INSERT INTO event_eligibility_rules (event_id, allowed_majors, allowed_years, allowed_role_ids, require_student_id_verified, organization_members_only)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (event_id) DO UPDATE SET
+			allowed_majors = EXCLUDED.allowed_majors,
+			allowed_years = EXCLUDED.allowed_years,
+			allowed_role_ids = EXCLUDED.allowed_role_ids,
+			require_student_id_verified = EXCLUDED.require_student_id_verified,
+			organization_members_only = EXCLUDED.organization_members_only,
+			updated_at = NOW()
+		RETURNING updated_at`,
+		eligibility.EventID, eligibility.AllowedMajors, eligibility.AllowedYears, eligibility.AllowedRoleIDs,
+		eligibility.RequireStudentIDVerified, eligibility.OrganizationMembersOnly).Scan(&eligibility.UpdatedAt)
+}
+
+// GetEligibilityProfile retrieves the user attributes used to evaluate eligibility rules
+func GetEligibilityProfile(userID uuid.UUID) (*models.EligibilityProfile, error) {
+	var profile models.EligibilityProfile
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT u.major, u.year, u.role_id, r.name, u.student_id_verified
+		FROM users u
+		JOIN roles r ON u.role_id = r.id
+		WHERE u.id = $1`, userID).Scan(
+		&profile.Major, &profile.Year, &profile.RoleID, &profile.RoleName, &profile.StudentIDVerified)
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// GetFeedbackSurvey retrieves the feedback survey of an event
+func GetFeedbackSurvey(eventID int) (*models.EventFeedbackSurvey, error) {
+	survey := models.EventFeedbackSurvey{EventID: eventID}
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT questions, checked_in_only, updated_at
+		FROM event_feedback_surveys WHERE event_id = $1`, eventID).Scan(
+		&survey.Questions, &survey.CheckedInOnly, &survey.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "This event has no feedback survey"}
+		}
+		return nil, err
+	}
+
+	return &survey, nil
+}
+
+// UpsertFeedbackSurvey creates or replaces the feedback survey of an event
+func UpsertFeedbackSurvey(survey *models.EventFeedbackSurvey) error {
+	return database.DB.QueryRow(context.Background(), `
+		INSERT INTO event_feedback_surveys (event_id, questions, checked_in_only)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_id) DO UPDATE SET
+			questions = EXCLUDED.questions,
+			checked_in_only = EXCLUDED.checked_in_only,
+			updated_at = NOW()
+		RETURNING updated_at`,
+		survey.EventID, survey.Questions, survey.CheckedInOnly).Scan(&survey.UpdatedAt)
+}
+
+// GetRegistrationCheckIn tells whether a user is registered for an event and when they checked in
+func GetRegistrationCheckIn(eventID int, userID uuid.UUID) (bool, *time.Time, error) {
+	var checkedInAt *time.Time
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT checked_in_at FROM event_registrations WHERE event_id = $1 AND user_id = $2`, eventID, userID).Scan(&checkedInAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil, nil
+		}
+		return false, nil, err
+	}
+
+	return true, checkedInAt, nil
+}
+
+// CreateEventFeedback stores the feedback of a user, each user submits feedback once per event
+func CreateEventFeedback(feedback *models.EventFeedback) error {
+	err := database.DB.QueryRow(context.Background(), `
+		INSERT INTO event_feedback (event_id, user_id, rating, answers)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, user_id) DO NOTHING
+		RETURNING id, submitted_at`,
+		feedback.EventID, feedback.UserID, feedback.Rating, feedback.Answers).Scan(&feedback.ID, &feedback.SubmittedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &utils.ConflictError{Message: "You have already submitted feedback for this event"}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ListEventFeedback retrieves every feedback submitted for an event
+func ListEventFeedback(eventID int) ([]*models.EventFeedback, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT id, event_id, user_id, rating, answers, submitted_at
+		FROM event_feedback WHERE event_id = $1
+		ORDER BY submitted_at`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var feedback []*models.EventFeedback
+	for rows.Next() {
+		var f models.EventFeedback
+		if err := rows.Scan(&f.ID, &f.EventID, &f.UserID, &f.Rating, &f.Answers, &f.SubmittedAt); err != nil {
+			return nil, err
+		}
+		feedback = append(feedback, &f)
+	}
+
+	return feedback, rows.Err()
+}
@@ -0,0 +1,119 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListEventHosts retrieves the hosts of several events at once, keyed by event id with the primary host first
+func ListEventHosts(eventIDs []int) (map[int][]models.EventHost, error) {
+	hosts := make(map[int][]models.EventHost, len(eventIDs))
+	if len(eventIDs) == 0 {
+		return hosts, nil
+	}
+
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT h.event_id, h.organization_id, o.name, h.is_primary
+		FROM event_hosts h
+		JOIN organizations o ON o.id = h.organization_id
+		WHERE h.event_id = ANY($1)
+		ORDER BY h.event_id, h.is_primary DESC, o.name`, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventID int
+		var host models.EventHost
+		if err := rows.Scan(&eventID, &host.OrganizationID, &host.Organization, &host.IsPrimary); err != nil {
+			return nil, err
+		}
+		hosts[eventID] = append(hosts[eventID], host)
+	}
+
+	return hosts, rows.Err()
+}
+
+// SetEventHosts replaces the hosts of an event. The primary host is stored on the event itself,
+// the events_primary_host trigger mirrors it into event_hosts.
+func SetEventHosts(eventID int, primaryOrganizationID int, coHostOrganizationIDs []int) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	organizationIDs := append([]int{primaryOrganizationID}, coHostOrganizationIDs...)
+
+	var found int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM organizations WHERE id = ANY($1)`, organizationIDs).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if found != len(organizationIDs) {
+		return utils.BadRequestError{Message: "Every host must be an existing organization"}
+	}
+
+	tag, err := tx.Exec(ctx, `UPDATE events SET organization_id = $1, updated_at = NOW() WHERE id = $2`, primaryOrganizationID, eventID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "Event not found"}
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM event_hosts WHERE event_id = $1 AND organization_id <> ALL($2)`, eventID, organizationIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, organizationID := range coHostOrganizationIDs {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO event_hosts (event_id, organization_id, is_primary) VALUES ($1, $2, FALSE)
+			ON CONFLICT (event_id, organization_id) DO UPDATE SET is_primary = FALSE`,
+			eventID, organizationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CanManageEvent tells whether a user may manage an event: admins, the author of the event
+// and the officers of any of its hosts, whose role is named after their organization
+func CanManageEvent(eventID int, userID uuid.UUID) (bool, error) {
+	var authorID uuid.UUID
+	err := database.DB.QueryRow(context.Background(), `SELECT user_id FROM events WHERE id = $1`, eventID).Scan(&authorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, &utils.NotFoundError{Message: "Event not found"}
+		}
+		return false, err
+	}
+	if authorID == userID {
+		return true, nil
+	}
+
+	var allowed bool
+	err = database.DB.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT 1 FROM users u
+			JOIN roles r ON r.id = u.role_id
+			WHERE u.id = $2 AND (
+				LOWER(r.name) = 'admin' OR EXISTS (
+					SELECT 1 FROM event_hosts h
+					JOIN organizations o ON o.id = h.organization_id
+					WHERE h.event_id = $1 AND LOWER(o.name) = LOWER(r.name)
+				)
+			)
+		)`, eventID, userID).Scan(&allowed)
+	return allowed, err
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// ListDueEventReminders retrieves the registrants of events starting after from and up to until
+// who have not received the given reminder yet and did not turn reminders off
+func ListDueEventReminders(ctx context.Context, reminder string, from, until time.Time) ([]*models.EventReminderRecipient, error) {
+	rows, err := database.DB.Query(ctx, `
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.all_day, e.status, e.slug, e.thumbnail, e.timezone, e.venue, e.online_meeting_url, o.name,
+		       u.id, u.email, u.first_name
+		FROM event_registrations er
+		JOIN events e ON e.id = er.event_id
+		JOIN users u ON u.id = er.user_id
+		LEFT JOIN organizations o ON o.id = e.organization_id
+		WHERE e.status = 'published' AND e.start_date > $1 AND e.start_date <= $2
+		  AND u.event_reminders_enabled
+		  AND NOT EXISTS (
+		      SELECT 1 FROM event_reminder_log l
+		      WHERE l.event_id = er.event_id AND l.user_id = er.user_id AND l.reminder = $3)
+		ORDER BY e.start_date`, from, until, reminder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// Events are shared by their registrants
+	events := make(map[int]*models.Event)
+
+	var recipients []*models.EventReminderRecipient
+	for rows.Next() {
+		var event models.Event
+		var organization *string
+		var recipient models.EventReminderRecipient
+		err := rows.Scan(
+			&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.AllDay, &event.Status, &event.Slug, &event.Thumbnail, &event.Timezone, &event.Venue, &event.OnlineMeetingURL, &organization,
+			&recipient.UserID, &recipient.Email, &recipient.FirstName)
+		if err != nil {
+			return nil, err
+		}
+
+		if organization != nil {
+			event.Organization = *organization
+		}
+		if _, ok := events[event.ID]; !ok {
+			events[event.ID] = &event
+		}
+		recipient.Event = events[event.ID]
+		recipients = append(recipients, &recipient)
+	}
+
+	return recipients, rows.Err()
+}
+
+// ClaimEventReminder records a reminder as sent, it returns false when it was already recorded
+func ClaimEventReminder(ctx context.Context, eventID int, userID uuid.UUID, reminder string) (bool, error) {
+	tag, err := database.DB.Exec(ctx, `
+		INSERT INTO event_reminder_log (event_id, user_id, reminder)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`, eventID, userID, reminder)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// ReleaseEventReminder removes a reminder from the log so it is sent again, used when sending failed
+func ReleaseEventReminder(ctx context.Context, eventID int, userID uuid.UUID, reminder string) error {
+	_, err := database.DB.Exec(ctx, `
+		DELETE FROM event_reminder_log WHERE event_id = $1 AND user_id = $2 AND reminder = $3`, eventID, userID, reminder)
+	return err
+}
+
+// GetEventRemindersEnabled tells whether a user receives event reminders
+func GetEventRemindersEnabled(userID uuid.UUID) (bool, error) {
+	var enabled bool
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT event_reminders_enabled FROM users WHERE id = $1`, userID).Scan(&enabled)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, &utils.NotFoundError{Message: "User not found"}
+		}
+		return false, err
+	}
+
+	return enabled, nil
+}
+
+// SetEventRemindersEnabled turns event reminders on or off for a user
+func SetEventRemindersEnabled(userID uuid.UUID, enabled bool) error {
+	tag, err := database.DB.Exec(context.Background(), `
+		UPDATE users SET event_reminders_enabled = $1 WHERE id = $2`, enabled, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "User not found"}
+	}
+
+	return nil
+}
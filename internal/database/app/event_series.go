@@ -0,0 +1,203 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// CreateEventSeries stores a series and materializes its occurrences as events in one transaction
+func CreateEventSeries(series *models.EventSeries, occurrences []*models.Event) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO event_series (title, recurrence_rule, exceptions, start_date, end_date, timezone, user_id, organization_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at`,
+		series.Title, series.RecurrenceRule, series.Exceptions, series.StartDate, series.EndDate, series.Timezone, series.UserID, series.OrganizationID).Scan(
+		&series.ID, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range occurrences {
+		event.SeriesID = &series.ID
+		err = tx.QueryRow(ctx, `
+			INSERT INTO events (title, description, start_date, end_date, user_id, status, slug, thumbnail, organization_id, max_registration, team_registration, min_team_size, max_team_size, open_for_all, series_id, timezone, venue, online_meeting_url, all_day, occurrence_date)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, ($3 AT TIME ZONE $16)::date)
+			RETURNING id, created_at, updated_at`,
+			event.Title, event.Description, event.StartDate, event.EndDate, event.UserID, event.Status, event.Slug, event.Thumbnail, event.OrganizationID, event.MaxRegistration, event.TeamRegistration, event.MinTeamSize, event.MaxTeamSize, event.OpenForAll, series.ID, event.Timezone, event.Venue, event.OnlineMeetingURL, event.AllDay).Scan(
+			&event.ID, &event.CreatedAt, &event.UpdatedAt)
+		if err != nil {
+			return slugConflict(err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetEventSeriesByID retrieves a series without its occurrences
+func GetEventSeriesByID(seriesID int) (*models.EventSeries, error) {
+	var series models.EventSeries
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT id, title, recurrence_rule, exceptions, start_date, end_date, timezone, user_id, organization_id, created_at, updated_at
+		FROM event_series WHERE id = $1`, seriesID).Scan(
+		&series.ID, &series.Title, &series.RecurrenceRule, &series.Exceptions, &series.StartDate, &series.EndDate, &series.Timezone,
+		&series.UserID, &series.OrganizationID, &series.CreatedAt, &series.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Event series not found"}
+		}
+		return nil, err
+	}
+
+	return &series, nil
+}
+
+// ListSeriesOccurrences retrieves the occurrences of a series in order.
+// When fromEventID is not zero only that occurrence and the ones generated after it are returned.
+func ListSeriesOccurrences(seriesID int, fromEventID int) ([]*models.Event, error) {
+	query := `
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.all_day, e.user_id, e.status, e.slug, e.thumbnail, e.created_at, e.updated_at, e.organization_id, e.max_registration, e.team_registration, e.min_team_size, e.max_team_size, e.open_for_all, e.series_id, e.timezone, e.venue, e.online_meeting_url, o.name AS organization, CONCAT(u.first_name, ' ', u.last_name) AS author
+		FROM events e
+		LEFT JOIN organizations o ON e.organization_id = o.id
+		LEFT JOIN users u ON e.user_id = u.id
+		WHERE e.series_id = $1`
+	args := []interface{}{seriesID}
+
+	if fromEventID != 0 {
+		query += ` AND e.occurrence_date >= (SELECT occurrence_date FROM events WHERE id = $2)`
+		args = append(args, fromEventID)
+	}
+	query += ` ORDER BY e.occurrence_date`
+
+	rows, err := database.DB.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		var event models.Event
+		err := rows.Scan(
+			&event.ID, &event.Title, &event.Description, &event.StartDate, &event.EndDate, &event.AllDay, &event.UserID, &event.Status, &event.Slug, &event.Thumbnail, &event.CreatedAt, &event.UpdatedAt, &event.OrganizationID, &event.MaxRegistration, &event.TeamRegistration, &event.MinTeamSize, &event.MaxTeamSize, &event.OpenForAll, &event.SeriesID, &event.Timezone, &event.Venue, &event.OnlineMeetingURL, &event.Organization, &event.Author)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// UpdateSeriesOccurrences updates several occurrences of a series and the series title in one transaction
+func UpdateSeriesOccurrences(seriesID int, title string, occurrences []*models.Event) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, event := range occurrences {
+		if _, err := tx.Exec(ctx, updateEventQuery, updateEventArgs(event.ID, event)...); err != nil {
+			return slugConflict(err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE event_series SET title = $1, updated_at = NOW() WHERE id = $2`, title, seriesID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// AddSeriesException excludes a date from a series and removes its occurrence, occurrences with registrations are kept
+func AddSeriesException(seriesID int, date time.Time) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE event_series
+		SET exceptions = CASE WHEN $2::date = ANY(exceptions) THEN exceptions ELSE array_append(exceptions, $2::date) END,
+		    updated_at = NOW()
+		WHERE id = $1`, seriesID, date)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "Event series not found"}
+	}
+
+	var eventID, registrations int
+	err = tx.QueryRow(ctx, `
+		SELECT e.id, (SELECT COUNT(*) FROM event_registrations er WHERE er.event_id = e.id)
+		FROM events e WHERE e.series_id = $1 AND e.occurrence_date = $2::date`, seriesID, date).Scan(&eventID, &registrations)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	if eventID != 0 {
+		if registrations > 0 {
+			return &utils.ConflictError{Message: "This occurrence already has registrations, cancel it instead of removing it"}
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM events WHERE id = $1`, eventID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteEventSeries deletes a series with its upcoming occurrences, past occurrences are kept as standalone events.
+// Nothing is deleted while an upcoming occurrence has registrations.
+func DeleteEventSeries(seriesID int) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the upcoming occurrences keeps registrations from being added until the series is gone
+	var registrations int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM event_registrations
+		WHERE event_id IN (SELECT id FROM events WHERE series_id = $1 AND start_date >= NOW() FOR UPDATE)`, seriesID).Scan(&registrations)
+	if err != nil {
+		return err
+	}
+	if registrations > 0 {
+		return &utils.ConflictError{Message: "Upcoming occurrences of this series already have registrations, cancel them instead of deleting the series"}
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM events WHERE series_id = $1 AND start_date >= NOW()`, seriesID)
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM event_series WHERE id = $1`, seriesID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "Event series not found"}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"context"
+	"time"
+)
+
+// eventStatusLockKey identifies the advisory lock held while event statuses are advanced,
+// so only one replica runs the transitions at a time
+const eventStatusLockKey = 2025032101
+
+// AdvanceEventStatuses moves published and open events along their dates with one UPDATE per transition.
+// It returns false without changing anything when another instance holds the lock.
+func AdvanceEventStatuses(ctx context.Context, now time.Time) (bool, int64, error) {
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var acquired bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, eventStatusLockKey).Scan(&acquired); err != nil {
+		return false, 0, err
+	}
+	if !acquired {
+		return false, 0, nil
+	}
+
+	ended, err := tx.Exec(ctx, `
+		UPDATE events SET status = 'ended'
+		WHERE status IN ('published', 'open') AND end_date <= $1`, now)
+	if err != nil {
+		return true, 0, err
+	}
+
+	opened, err := tx.Exec(ctx, `
+		UPDATE events SET status = 'open'
+		WHERE status = 'published' AND start_date <= $1 AND end_date > $1`, now)
+	if err != nil {
+		return true, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return true, 0, err
+	}
+
+	return true, ended.RowsAffected() + opened.RowsAffected(), nil
+}
+
+// NextEventStatusTransition returns when the next published or open event changes status, nil when none will
+func NextEventStatusTransition(ctx context.Context, now time.Time) (*time.Time, error) {
+	var next *time.Time
+	err := database.DB.QueryRow(ctx, `
+		SELECT MIN(transition_at)::timestamptz FROM (
+			SELECT MIN(start_date) AS transition_at FROM events WHERE status = 'published' AND start_date > $1
+			UNION ALL
+			SELECT MIN(end_date) FROM events WHERE status IN ('published', 'open') AND end_date > $1
+		) transitions`, now).Scan(&next)
+	if err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
@@ -0,0 +1,606 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"strings"
+	"time"
+)
+
+// countRegistrationSlots counts the registration slots used by an event, a registered team counts as a single slot
+func countRegistrationSlots(tx pgx.Tx, eventID int) (int, error) {
+	var count int
+	err := tx.QueryRow(context.Background(), `
+		SELECT COUNT(DISTINCT team_id) + COUNT(*) FILTER (WHERE team_id IS NULL)
+		FROM event_registrations WHERE event_id = $1`, eventID).Scan(&count)
+	return count, err
+}
+
+// checkUserAvailableForTeam makes sure the user is not on another team or already registered for the event
+func checkUserAvailableForTeam(tx pgx.Tx, eventID int, userID uuid.UUID) error {
+	var onTeam, registered bool
+	err := tx.QueryRow(context.Background(), `
+		SELECT EXISTS (SELECT 1 FROM event_team_members WHERE event_id = $1 AND user_id = $2),
+		       EXISTS (SELECT 1 FROM event_registrations WHERE event_id = $1 AND user_id = $2)`,
+		eventID, userID).Scan(&onTeam, &registered)
+	if err != nil {
+		return err
+	}
+
+	if onTeam {
+		return &utils.ConflictError{Message: "User is already on a team for this event"}
+	}
+	if registered {
+		return &utils.ConflictError{Message: "User is already registered for this event"}
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// CreateTeam creates a team for an event and adds its creator as captain
+func CreateTeam(team *models.EventTeam) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var teamRegistration bool
+	err = tx.QueryRow(ctx, `
+		SELECT team_registration FROM events WHERE id = $1`, team.EventID).Scan(&teamRegistration)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &utils.NotFoundError{Message: "Event not found"}
+		}
+		return err
+	}
+
+	if !teamRegistration {
+		return utils.BadRequestError{Message: "Event does not accept team registrations"}
+	}
+
+	if err := checkUserAvailableForTeam(tx, team.EventID, team.CaptainID); err != nil {
+		return err
+	}
+
+	var nameTaken bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM event_teams WHERE event_id = $1 AND LOWER(name) = LOWER($2))`,
+		team.EventID, team.Name).Scan(&nameTaken)
+	if err != nil {
+		return err
+	}
+
+	if nameTaken {
+		return &utils.ConflictError{Message: "Team name is already taken for this event"}
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO event_teams (event_id, name, captain_id)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at`,
+		team.EventID, team.Name, team.CaptainID).Scan(&team.ID, &team.CreatedAt, &team.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return &utils.ConflictError{Message: "Team name is already taken for this event"}
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO event_team_members (team_id, event_id, user_id, role)
+		VALUES ($1, $2, $3, $4)`,
+		team.ID, team.EventID, team.CaptainID, models.TeamRoleCaptain)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return &utils.ConflictError{Message: "User is already on a team for this event"}
+		}
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetTeamByID retrieves a team together with its members
+func GetTeamByID(teamID int) (*models.EventTeam, error) {
+	var team models.EventTeam
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT id, event_id, name, captain_id, registered_at, created_at, updated_at
+		FROM event_teams WHERE id = $1`, teamID).Scan(
+		&team.ID, &team.EventID, &team.Name, &team.CaptainID, &team.RegisteredAt, &team.CreatedAt, &team.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Team not found"}
+		}
+		return nil, err
+	}
+
+	members, err := listTeamMembers(`m.team_id = $1`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	team.Members = members[teamID]
+
+	return &team, nil
+}
+
+// GetTeamByEventAndUser retrieves the team a user belongs to for an event
+func GetTeamByEventAndUser(eventID int, userID uuid.UUID) (*models.EventTeam, error) {
+	var teamID int
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT team_id FROM event_team_members WHERE event_id = $1 AND user_id = $2`, eventID, userID).Scan(&teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "You are not on a team for this event"}
+		}
+		return nil, err
+	}
+
+	return GetTeamByID(teamID)
+}
+
+// ListTeamsByEvent retrieves all teams of an event together with their members
+func ListTeamsByEvent(eventID int) ([]*models.EventTeam, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT id, event_id, name, captain_id, registered_at, created_at, updated_at
+		FROM event_teams WHERE event_id = $1
+		ORDER BY created_at`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []*models.EventTeam
+	for rows.Next() {
+		var team models.EventTeam
+		err := rows.Scan(&team.ID, &team.EventID, &team.Name, &team.CaptainID, &team.RegisteredAt, &team.CreatedAt, &team.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, &team)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	members, err := listTeamMembers(`m.event_id = $1`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range teams {
+		team.Members = members[team.ID]
+	}
+
+	return teams, nil
+}
+
+// listTeamMembers retrieves team members matching the condition grouped by team ID
+func listTeamMembers(condition string, arg interface{}) (map[int][]*models.EventTeamMember, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT m.team_id, u.id, u.username, u.first_name, u.last_name, m.role, m.joined_at
+		FROM event_team_members m
+		JOIN users u ON m.user_id = u.id
+		WHERE `+condition+`
+		ORDER BY m.joined_at`, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make(map[int][]*models.EventTeamMember)
+	for rows.Next() {
+		var teamID int
+		var member models.EventTeamMember
+		err := rows.Scan(&teamID, &member.UserID, &member.Username, &member.FirstName, &member.LastName, &member.Role, &member.JoinedAt)
+		if err != nil {
+			return nil, err
+		}
+		members[teamID] = append(members[teamID], &member)
+	}
+
+	return members, rows.Err()
+}
+
+// CreateTeamInvitation invites a user, looked up by username or email, to join a team
+func CreateTeamInvitation(teamID int, invitedBy uuid.UUID, usernameOrEmail string) (*models.EventTeamInvitation, error) {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	invitation := models.EventTeamInvitation{TeamID: teamID, InvitedBy: invitedBy}
+
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM users WHERE LOWER(username) = LOWER($1) OR LOWER(email) = LOWER($1)`,
+		strings.TrimSpace(usernameOrEmail)).Scan(&invitation.InviteeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "User not found"}
+		}
+		return nil, err
+	}
+
+	if invitation.InviteeID == invitedBy {
+		return nil, utils.BadRequestError{Message: "You cannot invite yourself"}
+	}
+
+	var maxTeamSize *int
+	err = tx.QueryRow(ctx, `
+		SELECT t.event_id, t.name, e.title, e.max_team_size
+		FROM event_teams t
+		JOIN events e ON t.event_id = e.id
+		WHERE t.id = $1
+		FOR UPDATE OF t`, teamID).Scan(&invitation.EventID, &invitation.TeamName, &invitation.EventTitle, &maxTeamSize)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Team not found"}
+		}
+		return nil, err
+	}
+
+	if err := checkTeamHasRoom(tx, teamID, maxTeamSize); err != nil {
+		return nil, err
+	}
+
+	if err := checkUserAvailableForTeam(tx, invitation.EventID, invitation.InviteeID); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO event_team_invitations (team_id, event_id, invitee_id, invited_by, status)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, status, created_at`,
+		teamID, invitation.EventID, invitation.InviteeID, invitedBy, models.InvitationPending).Scan(
+		&invitation.ID, &invitation.Status, &invitation.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, &utils.ConflictError{Message: "User already has a pending invitation to this team"}
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// checkTeamHasRoom makes sure the team has not reached the maximum team size of its event
+func checkTeamHasRoom(tx pgx.Tx, teamID int, maxTeamSize *int) error {
+	if maxTeamSize == nil || *maxTeamSize <= 0 {
+		return nil
+	}
+
+	var members int
+	err := tx.QueryRow(context.Background(), `
+		SELECT COUNT(*) FROM event_team_members WHERE team_id = $1`, teamID).Scan(&members)
+	if err != nil {
+		return err
+	}
+
+	if members >= *maxTeamSize {
+		return &utils.ConflictError{Message: fmt.Sprintf("Team is full, the maximum team size is %d", *maxTeamSize)}
+	}
+	return nil
+}
+
+// ListTeamInvitationsByUser retrieves the pending team invitations of a user
+func ListTeamInvitationsByUser(userID uuid.UUID) ([]*models.EventTeamInvitation, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT i.id, i.team_id, t.name, i.event_id, e.title, i.invitee_id, i.invited_by, i.status, i.created_at, i.responded_at
+		FROM event_team_invitations i
+		JOIN event_teams t ON i.team_id = t.id
+		JOIN events e ON i.event_id = e.id
+		WHERE i.invitee_id = $1 AND i.status = $2
+		ORDER BY i.created_at DESC`, userID, models.InvitationPending)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*models.EventTeamInvitation
+	for rows.Next() {
+		var invitation models.EventTeamInvitation
+		err := rows.Scan(&invitation.ID, &invitation.TeamID, &invitation.TeamName, &invitation.EventID, &invitation.EventTitle,
+			&invitation.InviteeID, &invitation.InvitedBy, &invitation.Status, &invitation.CreatedAt, &invitation.RespondedAt)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, &invitation)
+	}
+
+	return invitations, rows.Err()
+}
+
+// GetTeamInvitationByID retrieves a team invitation
+func GetTeamInvitationByID(invitationID int) (*models.EventTeamInvitation, error) {
+	var invitation models.EventTeamInvitation
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT i.id, i.team_id, t.name, i.event_id, e.title, i.invitee_id, i.invited_by, i.status, i.created_at, i.responded_at
+		FROM event_team_invitations i
+		JOIN event_teams t ON i.team_id = t.id
+		JOIN events e ON i.event_id = e.id
+		WHERE i.id = $1`, invitationID).Scan(
+		&invitation.ID, &invitation.TeamID, &invitation.TeamName, &invitation.EventID, &invitation.EventTitle,
+		&invitation.InviteeID, &invitation.InvitedBy, &invitation.Status, &invitation.CreatedAt, &invitation.RespondedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Invitation not found"}
+		}
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// RespondToTeamInvitation accepts or declines a pending invitation. Accepting an invitation to an already
+// registered team registers the new member for the event as well.
+func RespondToTeamInvitation(invitationID int, userID uuid.UUID, accept bool) (*models.EventTeamInvitation, error) {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var invitation models.EventTeamInvitation
+	var registeredAt *time.Time
+	var maxTeamSize *int
+	err = tx.QueryRow(ctx, `
+		SELECT i.id, i.team_id, t.name, i.event_id, e.title, i.invitee_id, i.invited_by, i.status, i.created_at, t.registered_at, e.max_team_size
+		FROM event_team_invitations i
+		JOIN event_teams t ON i.team_id = t.id
+		JOIN events e ON i.event_id = e.id
+		WHERE i.id = $1
+		FOR UPDATE OF i, t`, invitationID).Scan(
+		&invitation.ID, &invitation.TeamID, &invitation.TeamName, &invitation.EventID, &invitation.EventTitle,
+		&invitation.InviteeID, &invitation.InvitedBy, &invitation.Status, &invitation.CreatedAt, &registeredAt, &maxTeamSize)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Invitation not found"}
+		}
+		return nil, err
+	}
+
+	if invitation.InviteeID != userID {
+		return nil, &utils.NotFoundError{Message: "Invitation not found"}
+	}
+
+	if invitation.Status != models.InvitationPending {
+		return nil, &utils.ConflictError{Message: "Invitation is no longer pending"}
+	}
+
+	invitation.Status = models.InvitationDeclined
+	if accept {
+		invitation.Status = models.InvitationAccepted
+
+		if err := checkUserAvailableForTeam(tx, invitation.EventID, userID); err != nil {
+			return nil, err
+		}
+
+		if err := checkTeamHasRoom(tx, invitation.TeamID, maxTeamSize); err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO event_team_members (team_id, event_id, user_id, role)
+			VALUES ($1, $2, $3, $4)`,
+			invitation.TeamID, invitation.EventID, userID, models.TeamRoleMember)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return nil, &utils.ConflictError{Message: "User is already on a team for this event"}
+			}
+			return nil, err
+		}
+
+		if registeredAt != nil {
+			_, err = tx.Exec(ctx, `
+				INSERT INTO event_registrations (event_id, user_id, registration_date, team_id)
+				VALUES ($1, $2, NOW(), $3)`, invitation.EventID, userID, invitation.TeamID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// A user can only join one team per event, so other pending invitations are void
+		_, err = tx.Exec(ctx, `
+			UPDATE event_team_invitations SET status = $1, responded_at = NOW()
+			WHERE event_id = $2 AND invitee_id = $3 AND status = $4 AND id <> $5`,
+			models.InvitationCancelled, invitation.EventID, userID, models.InvitationPending, invitation.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE event_team_invitations SET status = $1, responded_at = NOW()
+		WHERE id = $2
+		RETURNING responded_at`, invitation.Status, invitation.ID).Scan(&invitation.RespondedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// RemoveTeamMember removes a member from a team and cancels their registration if the team is registered
+func RemoveTeamMember(teamID int, userID uuid.UUID) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var registeredAt *time.Time
+	var minTeamSize *int
+	err = tx.QueryRow(ctx, `
+		SELECT t.registered_at, e.min_team_size
+		FROM event_teams t
+		JOIN events e ON t.event_id = e.id
+		WHERE t.id = $1
+		FOR UPDATE OF t`, teamID).Scan(&registeredAt, &minTeamSize)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &utils.NotFoundError{Message: "Team not found"}
+		}
+		return err
+	}
+
+	var role string
+	err = tx.QueryRow(ctx, `
+		SELECT role FROM event_team_members WHERE team_id = $1 AND user_id = $2`, teamID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &utils.NotFoundError{Message: "User is not a member of this team"}
+		}
+		return err
+	}
+
+	if role == models.TeamRoleCaptain {
+		return utils.BadRequestError{Message: "The captain cannot leave the team, disband the team instead"}
+	}
+
+	if registeredAt != nil && minTeamSize != nil {
+		var members int
+		err = tx.QueryRow(ctx, `
+			SELECT COUNT(*) FROM event_team_members WHERE team_id = $1`, teamID).Scan(&members)
+		if err != nil {
+			return err
+		}
+
+		if members-1 < *minTeamSize {
+			return &utils.ConflictError{Message: fmt.Sprintf("A registered team needs at least %d members", *minTeamSize)}
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM event_team_members WHERE team_id = $1 AND user_id = $2`, teamID, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM event_registrations WHERE team_id = $1 AND user_id = $2`, teamID, userID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteTeam deletes a team, its members, invitations and event registrations
+func DeleteTeam(teamID int) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM event_registrations WHERE team_id = $1`, teamID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM event_teams WHERE id = $1`, teamID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// RegisterTeam registers every member of a team for its event. The team takes a single slot of max_registration.
+func RegisterTeam(teamID int, additionalNotes string) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var eventID int
+	var registeredAt *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT event_id, registered_at FROM event_teams WHERE id = $1 FOR UPDATE`, teamID).Scan(&eventID, &registeredAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &utils.NotFoundError{Message: "Team not found"}
+		}
+		return err
+	}
+
+	if registeredAt != nil {
+		return &utils.ConflictError{Message: "Team is already registered for this event"}
+	}
+
+	// Lock the event so concurrent registrations cannot exceed max_registration
+	var maxRegistration, minTeamSize, maxTeamSize *int
+	err = tx.QueryRow(ctx, `
+		SELECT max_registration, min_team_size, max_team_size FROM events WHERE id = $1 FOR UPDATE`, eventID).Scan(
+		&maxRegistration, &minTeamSize, &maxTeamSize)
+	if err != nil {
+		return err
+	}
+
+	var members int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM event_team_members WHERE team_id = $1`, teamID).Scan(&members)
+	if err != nil {
+		return err
+	}
+
+	if minTeamSize != nil && members < *minTeamSize {
+		return utils.BadRequestError{Message: fmt.Sprintf("Team needs at least %d members to register", *minTeamSize)}
+	}
+	if maxTeamSize != nil && *maxTeamSize > 0 && members > *maxTeamSize {
+		return utils.BadRequestError{Message: fmt.Sprintf("Team can have at most %d members", *maxTeamSize)}
+	}
+
+	if maxRegistration != nil && *maxRegistration > 0 {
+		count, err := countRegistrationSlots(tx, eventID)
+		if err != nil {
+			return err
+		}
+
+		if count >= *maxRegistration {
+			return utils.MaxRegistrationReachedError{EventID: eventID}
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO event_registrations (event_id, user_id, registration_date, additional_notes, team_id)
+		SELECT event_id, user_id, NOW(), $2, team_id FROM event_team_members WHERE team_id = $1`,
+		teamID, additionalNotes)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE event_teams SET registered_at = NOW(), updated_at = NOW() WHERE id = $1`, teamID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
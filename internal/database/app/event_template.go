@@ -0,0 +1,167 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CreateEventCopy stores an event created from another event or a template along with
+// its eligibility rules and co-hosts in one transaction
+func CreateEventCopy(event *models.Event, eligibility *models.EventEligibility, coHostOrganizationIDs []int) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO events (title, description, start_date, end_date, user_id, status, slug, thumbnail, organization_id, max_registration, team_registration, min_team_size, max_team_size, open_for_all, timezone, venue, online_meeting_url, all_day)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING id, created_at, updated_at`,
+		event.Title, event.Description, event.StartDate, event.EndDate, event.UserID, event.Status, event.Slug, event.Thumbnail, event.OrganizationID, event.MaxRegistration, event.TeamRegistration, event.MinTeamSize, event.MaxTeamSize, event.OpenForAll, event.Timezone, event.Venue, event.OnlineMeetingURL, event.AllDay).Scan(
+		&event.ID, &event.CreatedAt, &event.UpdatedAt)
+	if err != nil {
+		return slugConflict(err)
+	}
+
+	if eligibility != nil {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO event_eligibility_rules (event_id, allowed_majors, allowed_years, allowed_role_ids, require_student_id_verified, organization_members_only)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			event.ID, eligibility.AllowedMajors, eligibility.AllowedYears, eligibility.AllowedRoleIDs,
+			eligibility.RequireStudentIDVerified, eligibility.OrganizationMembersOnly)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The primary host is added by the events_primary_host trigger
+	for _, organizationID := range coHostOrganizationIDs {
+		if organizationID == event.OrganizationID {
+			continue
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO event_hosts (event_id, organization_id, is_primary) VALUES ($1, $2, FALSE)
+			ON CONFLICT (event_id, organization_id) DO NOTHING`,
+			event.ID, organizationID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CreateEventTemplate stores a template, names are unique within an organization
+func CreateEventTemplate(template *models.EventTemplate) error {
+	err := database.DB.QueryRow(context.Background(), `
+		INSERT INTO event_templates (name, organization_id, user_id, thumbnail, settings)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at`,
+		template.Name, template.OrganizationID, template.UserID, template.Thumbnail, template.Settings).Scan(
+		&template.ID, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return &utils.ConflictError{Message: "A template with this name already exists"}
+		}
+		return err
+	}
+	return nil
+}
+
+// SetEventTemplateThumbnail stores the URL of the thumbnail copied for a template
+func SetEventTemplateThumbnail(templateID int, thumbnail string) error {
+	_, err := database.DB.Exec(context.Background(), `
+		UPDATE event_templates SET thumbnail = $1, updated_at = NOW() WHERE id = $2`, thumbnail, templateID)
+	return err
+}
+
+const selectEventTemplate = `
+	SELECT t.id, t.name, t.organization_id, o.name, t.user_id, t.thumbnail, t.settings, t.created_at, t.updated_at
+	FROM event_templates t
+	JOIN organizations o ON o.id = t.organization_id`
+
+func scanEventTemplate(row pgx.Row) (*models.EventTemplate, error) {
+	var template models.EventTemplate
+	err := row.Scan(&template.ID, &template.Name, &template.OrganizationID, &template.Organization, &template.UserID,
+		&template.Thumbnail, &template.Settings, &template.CreatedAt, &template.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetEventTemplate retrieves a template by its ID
+func GetEventTemplate(templateID int) (*models.EventTemplate, error) {
+	template, err := scanEventTemplate(database.DB.QueryRow(context.Background(), selectEventTemplate+` WHERE t.id = $1`, templateID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Template not found"}
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// ListEventTemplates retrieves the templates of an organization, organizationID 0 returns every template
+func ListEventTemplates(organizationID int) ([]*models.EventTemplate, error) {
+	query := selectEventTemplate
+	var args []interface{}
+	if organizationID != 0 {
+		query += ` WHERE t.organization_id = $1`
+		args = append(args, organizationID)
+	}
+	query += ` ORDER BY o.name, LOWER(t.name)`
+
+	rows, err := database.DB.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []*models.EventTemplate{}
+	for rows.Next() {
+		template, err := scanEventTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, rows.Err()
+}
+
+// DeleteEventTemplate deletes a template
+func DeleteEventTemplate(templateID int) error {
+	tag, err := database.DB.Exec(context.Background(), `DELETE FROM event_templates WHERE id = $1`, templateID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "Template not found"}
+	}
+	return nil
+}
+
+// CanManageOrganization tells whether a user is an admin or an officer of an organization,
+// officers have a role named after their organization
+func CanManageOrganization(organizationID int, userID uuid.UUID) (bool, error) {
+	var allowed bool
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT EXISTS (
+			SELECT 1 FROM users u
+			JOIN roles r ON r.id = u.role_id
+			LEFT JOIN organizations o ON o.id = $1
+			WHERE u.id = $2 AND (LOWER(r.name) = 'admin' OR LOWER(r.name) = LOWER(o.name))
+		)`, organizationID, userID).Scan(&allowed)
+	return allowed, err
+}
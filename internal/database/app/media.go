@@ -0,0 +1,107 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+// CreateMedia stores an uploaded media, it stays unlinked until an article referencing it is saved
+func CreateMedia(media *models.Media) error {
+	return database.DB.QueryRow(context.Background(), `
+		INSERT INTO media (id, user_id, url, content_type, size) VALUES ($1, $2, $3, $4, $5)
+		RETURNING created_at`,
+		media.ID, media.UserID, media.URL, media.ContentType, media.Size).Scan(&media.CreatedAt)
+}
+
+// SetNewsMedia links a news to the media referenced by its content, replacing its previous links
+func SetNewsMedia(newsID int, mediaIDs []uuid.UUID) error {
+	return setArticleMediaInTx("news_media", "news_id", newsID, mediaIDs)
+}
+
+// SetEventMedia links an event to the media referenced by its description, replacing its previous links
+func SetEventMedia(eventID int, mediaIDs []uuid.UUID) error {
+	return setArticleMediaInTx("event_media", "event_id", eventID, mediaIDs)
+}
+
+func setArticleMediaInTx(table, column string, articleID int, mediaIDs []uuid.UUID) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := setArticleMedia(ctx, tx, table, column, articleID, mediaIDs); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// setArticleMedia replaces the media links of an article. Ids of unknown media are ignored, and every media
+// linked or unlinked has its grace period restarted so an edit that is undone does not lose its images.
+func setArticleMedia(ctx context.Context, tx pgx.Tx, table, column string, articleID int, mediaIDs []uuid.UUID) error {
+	ids := mediaIDStrings(mediaIDs)
+
+	_, err := tx.Exec(ctx, fmt.Sprintf(`
+		WITH unlinked AS (
+			DELETE FROM %[1]s WHERE %[2]s = $1 AND media_id <> ALL($2::uuid[])
+			RETURNING media_id
+		)
+		UPDATE media SET last_used_at = NOW()
+		WHERE id IN (SELECT media_id FROM unlinked) OR id = ANY($2::uuid[])`, table, column), articleID, ids)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, fmt.Sprintf(`
+		INSERT INTO %[1]s (%[2]s, media_id)
+		SELECT $1, id FROM media WHERE id = ANY($2::uuid[])
+		ON CONFLICT DO NOTHING`, table, column), articleID, ids)
+	return err
+}
+
+// mediaIDStrings formats media ids for a uuid[] parameter
+func mediaIDStrings(mediaIDs []uuid.UUID) []string {
+	ids := make([]string, len(mediaIDs))
+	for i, id := range mediaIDs {
+		ids[i] = id.String()
+	}
+	return ids
+}
+
+// DeleteOrphanedMedia removes up to limit media linked to no article nor revision and unused since before,
+// returning them so their files can be deleted. Replicas running it at once remove different media.
+func DeleteOrphanedMedia(ctx context.Context, before time.Time, limit int) ([]*models.Media, error) {
+	rows, err := database.DB.Query(ctx, `
+		DELETE FROM media WHERE id IN (
+			SELECT m.id FROM media m
+			WHERE m.last_used_at < $1
+			  AND NOT EXISTS (SELECT 1 FROM news_media n WHERE n.media_id = m.id)
+			  AND NOT EXISTS (SELECT 1 FROM event_media e WHERE e.media_id = m.id)
+			  AND NOT EXISTS (SELECT 1 FROM news_revision_media r WHERE r.media_id = m.id)
+			ORDER BY m.last_used_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, url`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mediaList []*models.Media
+	for rows.Next() {
+		media := &models.Media{}
+		if err := rows.Scan(&media.ID, &media.URL); err != nil {
+			return nil, err
+		}
+		mediaList = append(mediaList, media)
+	}
+
+	return mediaList, rows.Err()
+}
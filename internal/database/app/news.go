@@ -7,20 +7,18 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"strconv"
+	"strings"
 )
 
 func CreateNews(news *models.News) error {
-	_, err := database.DB.Exec(context.Background(), `
-		INSERT INTO news (title, content, user_id, publish_date, thumbnail, slug, organization_id) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
-		news.Title, news.Content, news.UserID, news.PublishDate, news.Thumbnail, news.Slug, news.OrganizationID)
-	return err
-}
-
-func UpdateNews(newsID int, news *models.News) error {
-	_, err := database.DB.Exec(context.Background(), `
-		UPDATE news SET title = $1, content = $2, publish_date = $3, updated_at = $4, thumbnail = $5, slug = $6, organization_id = $7
-		WHERE id = $8`, news.Title, news.Content, news.PublishDate, news.UpdatedAt, news.Thumbnail, news.Slug, news.OrganizationID, newsID)
-	return err
+	err := database.DB.QueryRow(context.Background(), `
+		INSERT INTO news (title, content, user_id, publish_date, thumbnail, slug, organization_id, status, content_format, content_html, content_text, excerpt, reading_time)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, created_at, updated_at`,
+		news.Title, news.Content, news.UserID, news.PublishDate, news.Thumbnail, news.Slug, news.OrganizationID, news.Status,
+		news.ContentFormat, news.ContentHTML, news.ContentText, news.Excerpt, news.ReadingTime).Scan(
+		&news.ID, &news.CreatedAt, &news.UpdatedAt)
+	return slugConflict(err)
 }
 
 func DeleteNews(newsID int) error {
@@ -32,8 +30,10 @@ func DeleteNews(newsID int) error {
 func GetNewsByID(newsID int) (*models.News, error) {
 	var news models.News
 	err := database.DB.QueryRow(context.Background(), `
-		SELECT id, title, content, user_id, publish_date, likes, created_at, updated_at, thumbnail, slug, organization_id
-		FROM news WHERE id = $1`, newsID).Scan(&news.ID, &news.Title, &news.Content, &news.UserID, &news.PublishDate, &news.Likes, &news.CreatedAt, &news.UpdatedAt, &news.Thumbnail, &news.Slug, &news.OrganizationID)
+		SELECT id, title, content, user_id, publish_date, likes, views, created_at, updated_at, thumbnail, slug, organization_id, status,
+		       content_format, COALESCE(content_html, ''), excerpt, reading_time
+		FROM news WHERE id = $1`, newsID).Scan(&news.ID, &news.Title, &news.Content, &news.UserID, &news.PublishDate, &news.Likes, &news.Views, &news.CreatedAt, &news.UpdatedAt, &news.Thumbnail, &news.Slug, &news.OrganizationID, &news.Status,
+		&news.ContentFormat, &news.ContentHTML, &news.Excerpt, &news.ReadingTime)
 	if err != nil {
 		return nil, err
 	}
@@ -43,11 +43,13 @@ func GetNewsByID(newsID int) (*models.News, error) {
 func GetNewsBySlug(slug string) (*models.News, error) {
 	var news models.News
 	err := database.DB.QueryRow(context.Background(), `
-		SELECT n.id, n.title, n.content, n.user_id, n.publish_date, n.likes, n.created_at, n.updated_at, n.thumbnail, n.slug, n.organization_id, o.name as organizations, CONCAT(u.first_name, ' ', u.last_name) AS author
+		SELECT n.id, n.title, n.content, n.user_id, n.publish_date, n.likes, n.views, n.created_at, n.updated_at, n.thumbnail, n.slug, n.organization_id, o.name as organizations, CONCAT(u.first_name, ' ', u.last_name) AS author, n.status,
+		       n.content_format, COALESCE(n.content_html, ''), n.excerpt, n.reading_time
 		FROM news n
 		LEFT JOIN organizations o ON n.organization_id = o.id
 		LEFT JOIN users u ON n.user_id = u.id
-		WHERE n.slug = $1`, slug).Scan(&news.ID, &news.Title, &news.Content, &news.UserID, &news.PublishDate, &news.Likes, &news.CreatedAt, &news.UpdatedAt, &news.Thumbnail, &news.Slug, &news.OrganizationID, &news.Organization, &news.Author)
+		WHERE n.slug = $1`, slug).Scan(&news.ID, &news.Title, &news.Content, &news.UserID, &news.PublishDate, &news.Likes, &news.Views, &news.CreatedAt, &news.UpdatedAt, &news.Thumbnail, &news.Slug, &news.OrganizationID, &news.Organization, &news.Author, &news.Status,
+		&news.ContentFormat, &news.ContentHTML, &news.Excerpt, &news.ReadingTime)
 
 	if err != nil {
 		return nil, err
@@ -55,23 +57,53 @@ func GetNewsBySlug(slug string) (*models.News, error) {
 	return &news, nil
 }
 
-// ListNews returns a list of news based on the query parameters
+// ListNews returns a list of the published news based on the query parameters, news scheduled for later are left out
 func ListNews(queryParams map[string]string) ([]*models.News, int, error) {
 	limit := 10
 
 	query := `
-		SELECT n.id, n.title, n.content, n.user_id, n.publish_date, n.likes, n.created_at, n.updated_at, n.thumbnail, n.slug, n.organization_id, o.name as organizations, CONCAT(u.first_name, ' ', u.last_name) AS author
+		SELECT n.id, n.title, n.content, n.user_id, n.publish_date, n.likes, n.views, n.created_at, n.updated_at, n.thumbnail, n.slug, n.organization_id, o.name as organizations, CONCAT(u.first_name, ' ', u.last_name) AS author, n.status,
+		       n.content_format, COALESCE(n.content_html, ''), n.excerpt, n.reading_time
 		FROM news n
 		LEFT JOIN organizations o ON n.organization_id = o.id
-		LEFT JOIN users u ON n.user_id = u.id
-		WHERE 1 = 1`
+		LEFT JOIN users u ON n.user_id = u.id`
 
+	where := ` WHERE n.status = 'published' AND n.publish_date <= NOW()`
+	var args []interface{}
 	if queryParams["organization_id"] != "" {
-		query += " AND n.organization_id = " + queryParams["organization_id"]
+		organizationID, err := strconv.Atoi(queryParams["organization_id"])
+		if err != nil {
+			return nil, 0, err
+		}
+		args = append(args, organizationID)
+		where += fmt.Sprintf(" AND n.organization_id = $%d", len(args))
 	}
 
+	// tags is a comma separated list of tag slugs, news filed under any of them match unless tag_match=all.
+	// Slugs are counted once so a repeated slug does not raise the number of tags tag_match=all requires.
+	var slugs []string
+	seen := map[string]bool{}
+	for _, slug := range strings.Split(queryParams["tags"], ",") {
+		if slug = strings.ToLower(strings.TrimSpace(slug)); slug != "" && !seen[slug] {
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+	if len(slugs) > 0 {
+		args = append(args, slugs)
+		taggedWith := fmt.Sprintf(`SELECT COUNT(DISTINCT t.id) FROM news_tag_links l JOIN news_tags t ON t.id = l.tag_id
+			WHERE l.news_id = n.id AND t.slug = ANY($%d)`, len(args))
+		if queryParams["tag_match"] == "all" {
+			args = append(args, len(slugs))
+			where += fmt.Sprintf(" AND (%s) = $%d", taggedWith, len(args))
+		} else {
+			where += fmt.Sprintf(" AND (%s) > 0", taggedWith)
+		}
+	}
+	query += where
+
 	var totalRecords int
-	err := database.DB.QueryRow(context.Background(), ` SELECT COUNT(*) FROM news n WHERE 1 = 1`).Scan(&totalRecords)
+	err := database.DB.QueryRow(context.Background(), `SELECT COUNT(*) FROM news n`+where, args...).Scan(&totalRecords)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -83,12 +115,12 @@ func ListNews(queryParams map[string]string) ([]*models.News, int, error) {
 			return nil, totalPages, err
 		}
 		offset := (page - 1) * limit
-		query += fmt.Sprintf(" ORDER BY n.created_at DESC LIMIT %d OFFSET %d", limit, offset)
+		query += fmt.Sprintf(" ORDER BY n.publish_date DESC, n.id DESC LIMIT %d OFFSET %d", limit, offset)
 	} else {
-		query += fmt.Sprintf(" ORDER BY n.created_at DESC LIMIT %d", limit)
+		query += fmt.Sprintf(" ORDER BY n.publish_date DESC, n.id DESC LIMIT %d", limit)
 	}
 
-	rows, err := database.DB.Query(context.Background(), query)
+	rows, err := database.DB.Query(context.Background(), query, args...)
 	if err != nil {
 		return nil, totalPages, err
 	}
@@ -98,7 +130,8 @@ func ListNews(queryParams map[string]string) ([]*models.News, int, error) {
 	var news []*models.News
 	for rows.Next() {
 		var n models.News
-		err := rows.Scan(&n.ID, &n.Title, &n.Content, &n.UserID, &n.PublishDate, &n.Likes, &n.CreatedAt, &n.UpdatedAt, &n.Thumbnail, &n.Slug, &n.OrganizationID, &n.Organization, &n.Author)
+		err := rows.Scan(&n.ID, &n.Title, &n.Content, &n.UserID, &n.PublishDate, &n.Likes, &n.Views, &n.CreatedAt, &n.UpdatedAt, &n.Thumbnail, &n.Slug, &n.OrganizationID, &n.Organization, &n.Author, &n.Status,
+			&n.ContentFormat, &n.ContentHTML, &n.Excerpt, &n.ReadingTime)
 		if err != nil {
 			return nil, totalPages, err
 		}
@@ -107,14 +140,72 @@ func ListNews(queryParams map[string]string) ([]*models.News, int, error) {
 	return news, totalPages, nil
 }
 
-func LikeNews(userID uuid.UUID, newsID int) error {
-	_, err := database.DB.Exec(context.Background(), `
-		INSERT INTO news_likes (user_id, news_id) VALUES ($1, $2)`, userID, newsID)
-	return err
+// LikeNews likes a news for a user and returns its number of likes, liking it again changes nothing.
+// The counter is kept up to date by the news_likes_counter trigger.
+func LikeNews(userID uuid.UUID, newsID int) (int, error) {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO news_likes (user_id, news_id) VALUES ($1, $2)
+		ON CONFLICT (user_id, news_id) DO NOTHING`, userID, newsID)
+	if err != nil {
+		return 0, err
+	}
+
+	var likes int
+	if err := tx.QueryRow(ctx, `SELECT likes FROM news WHERE id = $1`, newsID).Scan(&likes); err != nil {
+		return 0, err
+	}
+
+	return likes, tx.Commit(ctx)
 }
 
-func UnlikeNews(userID uuid.UUID, newsID int) error {
-	_, err := database.DB.Exec(context.Background(), `
+// UnlikeNews removes the like of a user from a news and returns its number of likes,
+// unliking a news that is not liked changes nothing
+func UnlikeNews(userID uuid.UUID, newsID int) (int, error) {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
 		DELETE FROM news_likes WHERE user_id = $1 AND news_id = $2`, userID, newsID)
-	return err
+	if err != nil {
+		return 0, err
+	}
+
+	var likes int
+	if err := tx.QueryRow(ctx, `SELECT likes FROM news WHERE id = $1`, newsID).Scan(&likes); err != nil {
+		return 0, err
+	}
+
+	return likes, tx.Commit(ctx)
+}
+
+// ListLikedNewsIDs tells which of the given news a user likes
+func ListLikedNewsIDs(userID uuid.UUID, newsIDs []int) (map[int]bool, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT news_id FROM news_likes WHERE user_id = $1 AND news_id = ANY($2)`, userID, newsIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	liked := make(map[int]bool)
+	for rows.Next() {
+		var newsID int
+		if err := rows.Scan(&newsID); err != nil {
+			return nil, err
+		}
+		liked[newsID] = true
+	}
+
+	return liked, rows.Err()
 }
@@ -0,0 +1,224 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+const selectNewsComment = `
+	SELECT c.id, c.news_id, c.user_id, CONCAT(u.first_name, ' ', u.last_name) AS author, c.parent_id, c.content,
+	       c.hidden, c.deleted_at IS NOT NULL, c.created_at, c.updated_at
+	FROM news_comments c
+	LEFT JOIN users u ON u.id = c.user_id`
+
+func scanNewsComment(row pgx.Row) (*models.NewsComment, error) {
+	var comment models.NewsComment
+	err := row.Scan(&comment.ID, &comment.NewsID, &comment.UserID, &comment.Author, &comment.ParentID, &comment.Content,
+		&comment.Hidden, &comment.Deleted, &comment.CreatedAt, &comment.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// CreateNewsComment stores a comment or a reply
+func CreateNewsComment(comment *models.NewsComment) error {
+	return database.DB.QueryRow(context.Background(), `
+		INSERT INTO news_comments (news_id, user_id, parent_id, content) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`,
+		comment.NewsID, comment.UserID, comment.ParentID, comment.Content).Scan(&comment.ID, &comment.CreatedAt, &comment.UpdatedAt)
+}
+
+// CountNewsCommentsSince counts the comments a user wrote since a given time, deleted ones included
+func CountNewsCommentsSince(userID uuid.UUID, since time.Time) (int, error) {
+	var count int
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT COUNT(*) FROM news_comments WHERE user_id = $1 AND created_at >= $2`, userID, since).Scan(&count)
+	return count, err
+}
+
+// GetNewsComment retrieves a comment by its ID
+func GetNewsComment(commentID int) (*models.NewsComment, error) {
+	comment, err := scanNewsComment(database.DB.QueryRow(context.Background(), selectNewsComment+` WHERE c.id = $1`, commentID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Comment not found"}
+		}
+		return nil, err
+	}
+	return comment, nil
+}
+
+// ListNewsComments retrieves every comment of a news, oldest first
+func ListNewsComments(newsID int) ([]*models.NewsComment, error) {
+	rows, err := database.DB.Query(context.Background(), selectNewsComment+` WHERE c.news_id = $1 ORDER BY c.created_at, c.id`, newsID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []*models.NewsComment{}
+	for rows.Next() {
+		comment, err := scanNewsComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// UpdateNewsCommentContent replaces the content of a comment
+func UpdateNewsCommentContent(commentID int, content string) error {
+	_, err := database.DB.Exec(context.Background(), `
+		UPDATE news_comments SET content = $1, updated_at = NOW() WHERE id = $2`, content, commentID)
+	return err
+}
+
+// DeleteNewsComment deletes a comment and resolves its reports. A comment with replies is only emptied
+// and marked as deleted, a deleted parent left without replies is removed along with its last reply.
+func DeleteNewsComment(commentID int, deletedBy uuid.UUID) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE news_comment_reports SET status = 'resolved', resolved_by = $2, resolved_at = NOW()
+		WHERE comment_id = $1 AND status = 'open'`, commentID, deletedBy)
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE news_comments SET content = '', deleted_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND EXISTS (SELECT 1 FROM news_comments r WHERE r.parent_id = $1)`, commentID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		var parentID *int
+		err = tx.QueryRow(ctx, `DELETE FROM news_comments WHERE id = $1 RETURNING parent_id`, commentID).Scan(&parentID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return &utils.NotFoundError{Message: "Comment not found"}
+			}
+			return err
+		}
+
+		if parentID != nil {
+			_, err = tx.Exec(ctx, `
+				DELETE FROM news_comments p
+				WHERE p.id = $1 AND p.deleted_at IS NOT NULL
+				  AND NOT EXISTS (SELECT 1 FROM news_comments r WHERE r.parent_id = p.id)`, *parentID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SetNewsCommentHidden hides or shows a comment, hiding it resolves its open reports
+func SetNewsCommentHidden(commentID int, hidden bool, moderatorID uuid.UUID) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE news_comments
+		SET hidden = $2,
+		    hidden_by = CASE WHEN $2 THEN $3::uuid END,
+		    hidden_at = CASE WHEN $2 THEN NOW() END
+		WHERE id = $1`, commentID, hidden, moderatorID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "Comment not found"}
+	}
+
+	if hidden {
+		_, err = tx.Exec(ctx, `
+			UPDATE news_comment_reports SET status = 'resolved', resolved_by = $2, resolved_at = NOW()
+			WHERE comment_id = $1 AND status = 'open'`, commentID, moderatorID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ReportNewsComment records the report of a user, a user reports a comment only once
+func ReportNewsComment(commentID int, userID uuid.UUID, reason string) error {
+	_, err := database.DB.Exec(context.Background(), `
+		INSERT INTO news_comment_reports (comment_id, user_id, reason) VALUES ($1, $2, $3)
+		ON CONFLICT (comment_id, user_id) DO NOTHING`, commentID, userID, reason)
+	return err
+}
+
+// ListReportedNewsComments retrieves the moderation queue, the comments with open reports, most reported first
+func ListReportedNewsComments() ([]*models.ReportedNewsComment, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT c.id, c.news_id, c.user_id, CONCAT(u.first_name, ' ', u.last_name) AS author, c.parent_id, c.content,
+		       c.hidden, c.deleted_at IS NOT NULL, c.created_at, c.updated_at,
+		       n.title, n.slug, r.reports, r.reasons, r.last_reported_at
+		FROM (
+			SELECT comment_id, COUNT(*) AS reports, ARRAY_AGG(reason ORDER BY created_at) AS reasons, MAX(created_at) AS last_reported_at
+			FROM news_comment_reports
+			WHERE status = 'open'
+			GROUP BY comment_id
+		) r
+		JOIN news_comments c ON c.id = r.comment_id
+		JOIN news n ON n.id = c.news_id
+		LEFT JOIN users u ON u.id = c.user_id
+		ORDER BY r.reports DESC, r.last_reported_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	queue := []*models.ReportedNewsComment{}
+	for rows.Next() {
+		var item models.ReportedNewsComment
+		comment := &item.Comment
+		err := rows.Scan(&comment.ID, &comment.NewsID, &comment.UserID, &comment.Author, &comment.ParentID, &comment.Content,
+			&comment.Hidden, &comment.Deleted, &comment.CreatedAt, &comment.UpdatedAt,
+			&item.NewsTitle, &item.NewsSlug, &item.Reports, &item.Reasons, &item.LastReportedAt)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, &item)
+	}
+
+	return queue, rows.Err()
+}
+
+// DismissNewsCommentReports closes the open reports of a comment without acting on it
+func DismissNewsCommentReports(commentID int, moderatorID uuid.UUID) error {
+	tag, err := database.DB.Exec(context.Background(), `
+		UPDATE news_comment_reports SET status = 'dismissed', resolved_by = $2, resolved_at = NOW()
+		WHERE comment_id = $1 AND status = 'open'`, commentID, moderatorID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "Comment has no open reports"}
+	}
+	return nil
+}
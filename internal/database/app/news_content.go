@@ -0,0 +1,39 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"context"
+)
+
+// ListNewsWithoutHTML returns up to limit news whose content has not been rendered yet, or whose text is missing
+func ListNewsWithoutHTML(ctx context.Context, limit int) ([]*models.News, error) {
+	rows, err := database.DB.Query(ctx, `
+		SELECT id, content, content_format FROM news
+		WHERE content_html IS NULL OR content_text IS NULL
+		ORDER BY id
+		LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var newsList []*models.News
+	for rows.Next() {
+		news := &models.News{}
+		if err := rows.Scan(&news.ID, &news.Content, &news.ContentFormat); err != nil {
+			return nil, err
+		}
+		newsList = append(newsList, news)
+	}
+
+	return newsList, rows.Err()
+}
+
+// SetNewsRenderedContent stores the rendering of a news content without touching its updated_at
+func SetNewsRenderedContent(ctx context.Context, news *models.News) error {
+	_, err := database.DB.Exec(ctx, `
+		UPDATE news SET content_html = $1, content_text = $2, excerpt = $3, reading_time = $4
+		WHERE id = $5`, news.ContentHTML, news.ContentText, news.Excerpt, news.ReadingTime, news.ID)
+	return err
+}
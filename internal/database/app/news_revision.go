@@ -0,0 +1,177 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const selectNewsRevision = `
+	SELECT r.id, r.news_id, r.revision, r.title, r.content, r.content_format, r.thumbnail, r.thumbnail_key,
+	       r.editor_id, TRIM(CONCAT(u.first_name, ' ', u.last_name)), r.restored_from, r.created_at
+	FROM news_revisions r
+	LEFT JOIN users u ON r.editor_id = u.id`
+
+func scanNewsRevision(row pgx.Row) (*models.NewsRevision, error) {
+	var revision models.NewsRevision
+	err := row.Scan(&revision.ID, &revision.NewsID, &revision.Revision, &revision.Title, &revision.Content, &revision.ContentFormat,
+		&revision.Thumbnail, &revision.ThumbnailKey, &revision.EditorID, &revision.Editor, &revision.RestoredFrom, &revision.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// CreateNewsRevision stores a revision numbered after the latest one of its news, linked to the media of its content
+func CreateNewsRevision(revision *models.NewsRevision, mediaIDs []uuid.UUID) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertNewsRevision(ctx, tx, revision, mediaIDs); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// insertNewsRevision stores a revision and links it to the media it references, so they stay restorable
+func insertNewsRevision(ctx context.Context, tx pgx.Tx, revision *models.NewsRevision, mediaIDs []uuid.UUID) error {
+	err := tx.QueryRow(ctx, `
+		INSERT INTO news_revisions (news_id, revision, title, content, content_format, thumbnail, thumbnail_key, editor_id, restored_from)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3, $4, $5, $6, $7, $8 FROM news_revisions WHERE news_id = $1
+		RETURNING id, revision, created_at`,
+		revision.NewsID, revision.Title, revision.Content, revision.ContentFormat, revision.Thumbnail, revision.ThumbnailKey,
+		revision.EditorID, revision.RestoredFrom).Scan(&revision.ID, &revision.Revision, &revision.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return &utils.ConflictError{Message: "The news was edited by someone else at the same time, please try again"}
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO news_revision_media (revision_id, media_id)
+		SELECT $1, id FROM media WHERE id = ANY($2::uuid[])
+		ON CONFLICT DO NOTHING`, revision.ID, mediaIDStrings(mediaIDs))
+	return err
+}
+
+// SaveNewsEdit updates a news, links it to the media of its content and stores its revision in one transaction.
+// basedOn is the number of the latest revision when the edit started: when another edit was saved since,
+// nothing is written and a ConflictError is returned. revision is nil when the edit changes nothing revisions keep.
+func SaveNewsEdit(news *models.News, mediaIDs []uuid.UUID, revision *models.NewsRevision, basedOn int) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Locking the news makes concurrent edits wait for each other
+	var latest int
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE((SELECT MAX(revision) FROM news_revisions WHERE news_id = n.id), 0)
+		FROM news n WHERE n.id = $1 FOR UPDATE`, news.ID).Scan(&latest)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &utils.NotFoundError{Message: "News not found"}
+		}
+		return err
+	}
+	if latest != basedOn {
+		return &utils.ConflictError{Message: "The news was edited by someone else at the same time, please try again"}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE news SET title = $1, content = $2, publish_date = $3, updated_at = $4, thumbnail = $5, slug = $6, organization_id = $7, status = $8,
+		content_format = $9, content_html = $10, content_text = $11, excerpt = $12, reading_time = $13
+		WHERE id = $14`, news.Title, news.Content, news.PublishDate, news.UpdatedAt, news.Thumbnail, news.Slug, news.OrganizationID, news.Status,
+		news.ContentFormat, news.ContentHTML, news.ContentText, news.Excerpt, news.ReadingTime, news.ID)
+	if err != nil {
+		return slugConflict(err)
+	}
+
+	if err := setArticleMedia(ctx, tx, "news_media", "news_id", news.ID, mediaIDs); err != nil {
+		return err
+	}
+
+	if revision != nil {
+		if err := insertNewsRevision(ctx, tx, revision, mediaIDs); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetNewsRevision retrieves a revision of a news by its number
+func GetNewsRevision(newsID, revision int) (*models.NewsRevision, error) {
+	found, err := scanNewsRevision(database.DB.QueryRow(context.Background(),
+		selectNewsRevision+` WHERE r.news_id = $1 AND r.revision = $2`, newsID, revision))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, &utils.NotFoundError{Message: "Revision not found"}
+	}
+	return found, err
+}
+
+// GetLatestNewsRevision retrieves the latest revision of a news, nil when it has none
+func GetLatestNewsRevision(newsID int) (*models.NewsRevision, error) {
+	found, err := scanNewsRevision(database.DB.QueryRow(context.Background(),
+		selectNewsRevision+` WHERE r.news_id = $1 ORDER BY r.revision DESC LIMIT 1`, newsID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return found, err
+}
+
+// ListNewsRevisions returns the revisions of a news without their content, latest first
+func ListNewsRevisions(newsID int) ([]*models.NewsRevision, error) {
+	rows, err := database.DB.Query(context.Background(),
+		selectNewsRevision+` WHERE r.news_id = $1 ORDER BY r.revision DESC`, newsID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*models.NewsRevision{}
+	for rows.Next() {
+		revision, err := scanNewsRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revision.Content = ""
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// ListNewsRevisionThumbnailKeys returns the keys of the thumbnail copies of a news, each once
+func ListNewsRevisionThumbnailKeys(newsID int) ([]string, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT DISTINCT thumbnail_key FROM news_revisions WHERE news_id = $1 AND thumbnail_key IS NOT NULL`, newsID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []string{}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"time"
+)
+
+// newsPublishLockKey identifies the advisory lock held while scheduled news are published,
+// so only one replica publishes them at a time
+const newsPublishLockKey = 2025032102
+
+// UpdateNewsStatus sets the status and the publish date of a news
+func UpdateNewsStatus(newsID int, status models.NewsStatus, publishDate time.Time) error {
+	tag, err := database.DB.Exec(context.Background(), `
+		UPDATE news SET status = $1, publish_date = $2, updated_at = NOW() WHERE id = $3`,
+		status, publishDate, newsID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "News not found"}
+	}
+	return nil
+}
+
+// PublishScheduledNews publishes the scheduled news whose publish date has passed.
+// It returns false without changing anything when another instance holds the lock.
+func PublishScheduledNews(ctx context.Context, now time.Time) (bool, int64, error) {
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var acquired bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, newsPublishLockKey).Scan(&acquired); err != nil {
+		return false, 0, err
+	}
+	if !acquired {
+		return false, 0, nil
+	}
+
+	published, err := tx.Exec(ctx, `
+		UPDATE news SET status = 'published'
+		WHERE status = 'scheduled' AND publish_date <= $1`, now)
+	if err != nil {
+		return true, 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return true, 0, err
+	}
+
+	return true, published.RowsAffected(), nil
+}
+
+// NextScheduledNews returns when the next scheduled news goes live, nil when none is scheduled
+func NextScheduledNews(ctx context.Context, now time.Time) (*time.Time, error) {
+	var next *time.Time
+	err := database.DB.QueryRow(ctx, `
+		SELECT MIN(publish_date) FROM news WHERE status = 'scheduled' AND publish_date > $1`, now).Scan(&next)
+	if err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const selectNewsTag = `SELECT t.id, t.name, t.slug, t.kind, t.description, t.created_at, t.updated_at FROM news_tags t`
+
+func scanNewsTag(row pgx.Row) (*models.NewsTag, error) {
+	var tag models.NewsTag
+	if err := row.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.Kind, &tag.Description, &tag.CreatedAt, &tag.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// newsTagConflict turns a violation of the unique slug of tags into a ConflictError
+func newsTagConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return &utils.ConflictError{Message: "A tag with this name already exists"}
+	}
+	return err
+}
+
+// CreateNewsTag stores a tag or a category
+func CreateNewsTag(tag *models.NewsTag) error {
+	err := database.DB.QueryRow(context.Background(), `
+		INSERT INTO news_tags (name, slug, kind, description) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at`,
+		tag.Name, tag.Slug, tag.Kind, tag.Description).Scan(&tag.ID, &tag.CreatedAt, &tag.UpdatedAt)
+	return newsTagConflict(err)
+}
+
+// UpdateNewsTag renames a tag and updates its kind and description
+func UpdateNewsTag(tag *models.NewsTag) error {
+	err := database.DB.QueryRow(context.Background(), `
+		UPDATE news_tags SET name = $1, slug = $2, kind = $3, description = $4, updated_at = NOW()
+		WHERE id = $5
+		RETURNING created_at, updated_at`,
+		tag.Name, tag.Slug, tag.Kind, tag.Description, tag.ID).Scan(&tag.CreatedAt, &tag.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &utils.NotFoundError{Message: "Tag not found"}
+	}
+	return newsTagConflict(err)
+}
+
+// DeleteNewsTag deletes a tag, the news filed under it lose it
+func DeleteNewsTag(tagID int) error {
+	tag, err := database.DB.Exec(context.Background(), `DELETE FROM news_tags WHERE id = $1`, tagID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return &utils.NotFoundError{Message: "Tag not found"}
+	}
+	return nil
+}
+
+// ListNewsTags retrieves the tags sorted by name, kind is "tag", "category" or empty for both
+func ListNewsTags(kind string) ([]*models.NewsTag, error) {
+	query := selectNewsTag
+	var args []interface{}
+	if kind != "" {
+		query += ` WHERE t.kind = $1`
+		args = append(args, kind)
+	}
+	query += ` ORDER BY LOWER(t.name)`
+
+	rows, err := database.DB.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []*models.NewsTag{}
+	for rows.Next() {
+		tag, err := scanNewsTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// NewsTagCloud counts the published news filed under each tag, tags without any are left out
+func NewsTagCloud(kind string, limit int) ([]*models.NewsTag, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT t.id, t.name, t.slug, t.kind, t.description, t.created_at, t.updated_at, COUNT(*) AS count
+		FROM news_tags t
+		JOIN news_tag_links l ON l.tag_id = t.id
+		JOIN news n ON n.id = l.news_id
+		WHERE n.status = 'published' AND n.publish_date <= NOW() AND ($1 = '' OR t.kind = $1)
+		GROUP BY t.id
+		ORDER BY count DESC, LOWER(t.name)
+		LIMIT $2`, kind, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []*models.NewsTag{}
+	for rows.Next() {
+		var tag models.NewsTag
+		err := rows.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.Kind, &tag.Description, &tag.CreatedAt, &tag.UpdatedAt, &tag.Count)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, &tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// NewsTagsExist tells whether every one of the given distinct tag IDs exists
+func NewsTagsExist(tagIDs []int) (bool, error) {
+	var found int
+	err := database.DB.QueryRow(context.Background(), `SELECT COUNT(*) FROM news_tags WHERE id = ANY($1)`, tagIDs).Scan(&found)
+	return found == len(tagIDs), err
+}
+
+// SetNewsTags replaces the tags of a news
+func SetNewsTags(newsID int, tagIDs []int) error {
+	ctx := context.Background()
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM news_tag_links WHERE news_id = $1`, newsID); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO news_tag_links (news_id, tag_id) SELECT $1, UNNEST($2::int[])`, newsID, tagIDs)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListTagsOfNews retrieves the tags of several news at once, sorted by kind then name
+func ListTagsOfNews(newsIDs []int) (map[int][]models.NewsTag, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		SELECT l.news_id, t.id, t.name, t.slug, t.kind, t.description, t.created_at, t.updated_at
+		FROM news_tag_links l
+		JOIN news_tags t ON t.id = l.tag_id
+		WHERE l.news_id = ANY($1)
+		ORDER BY t.kind, LOWER(t.name)`, newsIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make(map[int][]models.NewsTag)
+	for rows.Next() {
+		var newsID int
+		var tag models.NewsTag
+		err := rows.Scan(&newsID, &tag.ID, &tag.Name, &tag.Slug, &tag.Kind, &tag.Description, &tag.CreatedAt, &tag.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		tags[newsID] = append(tags[newsID], tag)
+	}
+
+	return tags, rows.Err()
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"context"
+	"time"
+)
+
+// AddNewsViews adds buffered views to the counters of news and to their views of today, views of news deleted
+// in the meantime are dropped
+func AddNewsViews(ctx context.Context, views map[int]int64) error {
+	newsIDs := make([]int, 0, len(views))
+	counts := make([]int64, 0, len(views))
+	for newsID, count := range views {
+		newsIDs = append(newsIDs, newsID)
+		counts = append(counts, count)
+	}
+
+	tx, err := database.DB.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE news n SET views = n.views + v.views
+		FROM UNNEST($1::int[], $2::bigint[]) AS v (news_id, views)
+		WHERE n.id = v.news_id`, newsIDs, counts)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO news_daily_views (news_id, day, views)
+		SELECT v.news_id, CURRENT_DATE, v.views
+		FROM UNNEST($1::int[], $2::bigint[]) AS v (news_id, views)
+		JOIN news n ON n.id = v.news_id
+		ON CONFLICT (news_id, day) DO UPDATE SET views = news_daily_views.views + EXCLUDED.views`, newsIDs, counts)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListTrendingNews ranks the published news by their views and likes of the last days, each view counting
+// for one and each like for likeWeight. Activity loses half of its weight every halfLife.
+func ListTrendingNews(days, limit int, likeWeight float64, halfLife time.Duration) ([]*models.News, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		WITH activity AS (
+			SELECT news_id, views * POWER(0.5, (CURRENT_DATE - day) * 24 / $4::float) AS weight
+			FROM news_daily_views
+			WHERE day > CURRENT_DATE - $1::int
+			UNION ALL
+			SELECT news_id, $3::float * POWER(0.5, EXTRACT(EPOCH FROM NOW() - created_at) / 3600 / $4::float)
+			FROM news_likes
+			WHERE created_at > NOW() - MAKE_INTERVAL(days => $1::int)
+		), scores AS (
+			SELECT news_id, SUM(weight) AS score FROM activity GROUP BY news_id
+		)
+		SELECT n.id, n.title, n.content, n.user_id, n.publish_date, n.likes, n.views, n.created_at, n.updated_at, n.thumbnail, n.slug, n.organization_id, o.name as organizations, CONCAT(u.first_name, ' ', u.last_name) AS author, n.status,
+		       n.content_format, COALESCE(n.content_html, ''), n.excerpt, n.reading_time, s.score
+		FROM scores s
+		JOIN news n ON n.id = s.news_id
+		LEFT JOIN organizations o ON n.organization_id = o.id
+		LEFT JOIN users u ON n.user_id = u.id
+		WHERE n.status = 'published' AND n.publish_date <= NOW()
+		ORDER BY s.score DESC, n.publish_date DESC, n.id DESC
+		LIMIT $2`, days, limit, likeWeight, halfLife.Hours())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	news := []*models.News{}
+	for rows.Next() {
+		var n models.News
+		err := rows.Scan(&n.ID, &n.Title, &n.Content, &n.UserID, &n.PublishDate, &n.Likes, &n.Views, &n.CreatedAt, &n.UpdatedAt, &n.Thumbnail, &n.Slug, &n.OrganizationID, &n.Organization, &n.Author, &n.Status,
+			&n.ContentFormat, &n.ContentHTML, &n.Excerpt, &n.ReadingTime, &n.TrendingScore)
+		if err != nil {
+			return nil, err
+		}
+		news = append(news, &n)
+	}
+
+	return news, rows.Err()
+}
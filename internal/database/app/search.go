@@ -0,0 +1,73 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/internal/models"
+	"context"
+)
+
+// Search looks up the news, events and aspirations of the given types matching a web search query such as
+// `"career fair" -online`, best ranked first. Drafts, news not published yet, anonymous and closed aspirations
+// are left out. Highlighted words are wrapped in startSel and stopSel.
+func Search(query string, types []string, limit, offset int, startSel, stopSel string) ([]*models.SearchResult, int, error) {
+	rows, err := database.DB.Query(context.Background(), `
+		WITH q AS (
+			SELECT websearch_to_tsquery('simple', $1) AS query
+		), results AS (
+			SELECT 'news' AS type, n.id, n.slug, n.title,
+			       COALESCE(n.content_text, '') AS body,
+			       ts_rank_cd(n.search_vector, q.query) AS rank, n.publish_date AS date, COALESCE(o.name, '') AS organization
+			FROM news n
+			CROSS JOIN q
+			LEFT JOIN organizations o ON o.id = n.organization_id
+			WHERE 'news' = ANY($2) AND n.search_vector @@ q.query
+			  AND n.status = 'published' AND n.publish_date <= NOW()
+			UNION ALL
+			SELECT 'event', e.id, e.slug, e.title, COALESCE(e.description, ''),
+			       ts_rank_cd(e.search_vector, q.query), e.start_date, COALESCE(o.name, '')
+			FROM events e
+			CROSS JOIN q
+			LEFT JOIN organizations o ON o.id = e.organization_id
+			WHERE 'event' = ANY($2) AND e.search_vector @@ q.query
+			  AND e.status <> 'draft'
+			UNION ALL
+			SELECT 'aspiration', a.id, '', a.subject, '',
+			       ts_rank_cd(a.search_vector, q.query), a.created_at, COALESCE(o.name, '')
+			FROM aspirations a
+			CROSS JOIN q
+			LEFT JOIN organizations o ON o.id = a.organization_id
+			WHERE 'aspiration' = ANY($2) AND a.search_vector @@ q.query
+			  AND NOT a.anonymous AND NOT a.closed
+		), page AS (
+			SELECT *, COUNT(*) OVER () AS total FROM results
+			ORDER BY rank DESC, date DESC, type, id
+			LIMIT $3 OFFSET $4
+		)
+		SELECT page.type, page.id, page.slug, page.title,
+		       ts_headline('simple', page.title, q.query, 'HighlightAll=true, StartSel="' || $5 || '", StopSel="' || $6 || '"'),
+		       CASE WHEN page.body = '' THEN '' ELSE ts_headline('simple', page.body, q.query,
+		           'MaxFragments=2, MaxWords=25, MinWords=10, FragmentDelimiter=" … ", StartSel="' || $5 || '", StopSel="' || $6 || '"') END,
+		       page.organization, page.rank, page.date, page.total
+		FROM page
+		CROSS JOIN q
+		ORDER BY page.rank DESC, page.date DESC, page.type, page.id`,
+		query, types, limit, offset, startSel, stopSel)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	results := []*models.SearchResult{}
+	total := 0
+	for rows.Next() {
+		var result models.SearchResult
+		err := rows.Scan(&result.Type, &result.ID, &result.Slug, &result.Title, &result.TitleHighlight, &result.Snippet,
+			&result.Organization, &result.Rank, &result.Date, &total)
+		if err != nil {
+			return nil, 0, err
+		}
+		results = append(results, &result)
+	}
+
+	return results, total, rows.Err()
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"Backend/internal/database"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// EventSlugTaken tells whether another event than eventID uses a slug or used it before being renamed.
+// Old slugs stay reserved so links to renamed events keep redirecting.
+func EventSlugTaken(slug string, eventID int) (bool, error) {
+	var taken bool
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT EXISTS (SELECT 1 FROM events WHERE slug = $1 AND id <> $2)
+		    OR EXISTS (SELECT 1 FROM event_slug_history WHERE slug = $1 AND event_id <> $2)`,
+		slug, eventID).Scan(&taken)
+	return taken, err
+}
+
+// NewsSlugTaken tells whether another news than newsID uses a slug or used it before being renamed
+func NewsSlugTaken(slug string, newsID int) (bool, error) {
+	var taken bool
+	err := database.DB.QueryRow(context.Background(), `
+		SELECT EXISTS (SELECT 1 FROM news WHERE slug = $1 AND id <> $2)
+		    OR EXISTS (SELECT 1 FROM news_slug_history WHERE slug = $1 AND news_id <> $2)`,
+		slug, newsID).Scan(&taken)
+	return taken, err
+}
+
+// GetEventIDBySlugHistory finds the event that used a slug before being renamed
+func GetEventIDBySlugHistory(slug string) (int, error) {
+	var eventID int
+	err := database.DB.QueryRow(context.Background(), `SELECT event_id FROM event_slug_history WHERE slug = $1`, slug).Scan(&eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, &utils.NotFoundError{Message: "Event not found"}
+		}
+		return 0, err
+	}
+	return eventID, nil
+}
+
+// GetNewsIDBySlugHistory finds the news that used a slug before being renamed
+func GetNewsIDBySlugHistory(slug string) (int, error) {
+	var newsID int
+	err := database.DB.QueryRow(context.Background(), `SELECT news_id FROM news_slug_history WHERE slug = $1`, slug).Scan(&newsID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, &utils.NotFoundError{Message: "News not found"}
+		}
+		return 0, err
+	}
+	return newsID, nil
+}
+
+// slugConflict turns a violation of the unique slug indexes, when two items with the same title are saved
+// at the same time, into a ConflictError
+func slugConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && (pgErr.ConstraintName == "events_slug_idx" || pgErr.ConstraintName == "news_slug_idx") {
+		return &utils.ConflictError{Message: "Another item with the same slug was just saved, please try again"}
+	}
+	return err
+}
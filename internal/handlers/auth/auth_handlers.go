@@ -347,7 +347,7 @@ func (h *Handlers) ExtractUserIDAndCheckPermission(c *gin.Context, permissionTyp
 
 	if !hasPermission {
 		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{"Unauthorized"}})
-		return uuid.UUID{}, err
+		return uuid.UUID{}, errors.New("unauthorized")
 	}
 
 	return userID, nil
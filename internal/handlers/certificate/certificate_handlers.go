@@ -0,0 +1,284 @@
+package certificate
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"Backend/internal/services"
+	"Backend/pkg/utils"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type Handlers struct {
+	CertificateService *services.CertificateService
+	EventService       *services.EventService
+	PermissionService  *services.PermissionService
+}
+
+func NewCertificateHandlers(certificateService *services.CertificateService, eventService *services.EventService, permissionService *services.PermissionService) *Handlers {
+	return &Handlers{
+		CertificateService: certificateService,
+		EventService:       eventService,
+		PermissionService:  permissionService,
+	}
+}
+
+// errorStatus maps certificate errors to their HTTP status code
+func errorStatus(err error) int {
+	var badRequest utils.BadRequestError
+	var unauthorized utils.UnauthorizedError
+	var notFound *utils.NotFoundError
+	var conflict *utils.ConflictError
+
+	switch {
+	case errors.As(err, &badRequest):
+		return http.StatusBadRequest
+	case errors.As(err, &unauthorized):
+		return http.StatusForbidden
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	case errors.As(err, &conflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// requireEventManager responds with an error and returns false unless the user may manage the event
+func (h *Handlers) requireEventManager(c *gin.Context, eventID int, userID uuid.UUID) bool {
+	if err := h.EventService.RequireEventManager(eventID, userID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return false
+	}
+	return true
+}
+
+// GetTemplate retrieves the certificate template of an event
+func (h *Handlers) GetTemplate(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	template, err := h.CertificateService.GetTemplate(eventID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Certificate Template Retrieved Successfully",
+		"data":    template,
+	})
+}
+
+// UpdateTemplate creates or replaces the layout of the certificates of an event
+func (h *Handlers) UpdateTemplate(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	var template models.CertificateTemplate
+	if err := c.BindJSON(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+	template.EventID = eventID
+
+	if err := h.CertificateService.UpdateTemplate(&template); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Certificate Template Updated Successfully",
+		"data":    template,
+	})
+}
+
+// UploadBackground stores the background image of the certificates of an event, it is converted to JPEG
+func (h *Handlers) UploadBackground(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"No background image provided"}})
+		return
+	}
+	defer file.Close()
+
+	optimizedImage, err := utils.OptimizeImage(file, 3508, 3508)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	optimizedImageBytes, err := io.ReadAll(optimizedImage)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	backgroundURL, err := h.CertificateService.UploadBackground(c.Request.Context(), eventID, optimizedImageBytes)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Certificate Background Uploaded Successfully",
+		"data":    gin.H{"background_url": backgroundURL},
+	})
+}
+
+// GenerateCertificates issues the certificates of an event to everyone who has none yet
+func (h *Handlers) GenerateCertificates(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	certificates, err := h.CertificateService.GenerateCertificates(c.Request.Context(), eventID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}, "issued": len(certificates)})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Certificates Generated Successfully",
+		"data":    certificates,
+		"issued":  len(certificates),
+	})
+}
+
+// ListEventCertificates retrieves the certificates issued for an event
+func (h *Handlers) ListEventCertificates(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	certificates, err := h.CertificateService.ListEventCertificates(eventID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Certificates Retrieved Successfully",
+		"data":    certificates,
+	})
+}
+
+// ListMyCertificates retrieves the certificates issued to the current user
+func (h *Handlers) ListMyCertificates(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "users:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	certificates, err := h.CertificateService.ListUserCertificates(userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Certificates Retrieved Successfully",
+		"data":    certificates,
+	})
+}
+
+// VerifyCertificate lets anyone check a certificate with its code
+func (h *Handlers) VerifyCertificate(c *gin.Context) {
+	certificate, err := h.CertificateService.VerifyCertificate(c.Param("code"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Certificate Verified Successfully",
+		"data": gin.H{
+			"code":             certificate.Code,
+			"recipient_name":   certificate.RecipientName,
+			"event_title":      certificate.EventTitle,
+			"event_start_date": certificate.EventStartDate,
+			"organization":     certificate.Organization,
+			"issued_at":        certificate.IssuedAt,
+			"file_url":         certificate.FileURL,
+			"verification_url": certificate.VerificationURL,
+		},
+	})
+}
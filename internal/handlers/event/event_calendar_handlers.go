@@ -0,0 +1,118 @@
+package event
+
+import (
+	"Backend/internal/handlers/auth"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// writeCalendarFeed writes a subscribable feed, calendar apps poll feeds so they may be cached for a while
+func writeCalendarFeed(c *gin.Context, calendar []byte, err error) {
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=900")
+	c.Data(http.StatusOK, calendarContentType, calendar)
+}
+
+// GetEventICalendar downloads a single event, looked up by slug, as an .ics file
+func (h *Handlers) GetEventICalendar(c *gin.Context) {
+	slug := c.Param("eventID")
+
+	calendar, err := h.EventService.EventICalendar(slug)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+slug+`.ics"`)
+	c.Data(http.StatusOK, calendarContentType, calendar)
+}
+
+// PublicEventsFeed is the calendar feed of all events
+func (h *Handlers) PublicEventsFeed(c *gin.Context) {
+	calendar, err := h.EventService.PublicEventsICalendar()
+	writeCalendarFeed(c, calendar, err)
+}
+
+// OrganizationEventsFeed is the calendar feed of the events of one organization
+func (h *Handlers) OrganizationEventsFeed(c *gin.Context) {
+	organizationID, err := strconv.Atoi(strings.TrimSuffix(c.Param("organizationID"), ".ics"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Organization ID"}})
+		return
+	}
+
+	calendar, err := h.EventService.OrganizationEventsICalendar(organizationID)
+	writeCalendarFeed(c, calendar, err)
+}
+
+// UserEventsFeed is the calendar feed of the events a user registered for.
+// Calendar apps cannot send tokens, so the user is identified by the secret feed token in the URL.
+func (h *Handlers) UserEventsFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := h.EventService.UserEventsICalendar(token)
+	if err == nil {
+		// Personal feeds must not be kept by shared caches
+		c.Header("Cache-Control", "private, max-age=900")
+		c.Data(http.StatusOK, calendarContentType, calendar)
+		return
+	}
+
+	c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+}
+
+// GetCalendarFeedToken returns the secret token of the personal calendar feed of the user
+func (h *Handlers) GetCalendarFeedToken(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "users:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	token, err := h.EventService.GetCalendarFeedToken(userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Calendar Feed Token Retrieved Successfully",
+		"data": gin.H{
+			"token": token,
+			"path":  "/api/v1/event/feeds/user/" + token + ".ics",
+		},
+	})
+}
+
+// RotateCalendarFeedToken replaces the calendar feed token, existing subscriptions stop working
+func (h *Handlers) RotateCalendarFeedToken(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "users:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	token, err := h.EventService.RotateCalendarFeedToken(userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Calendar Feed Token Rotated Successfully",
+		"data": gin.H{
+			"token": token,
+			"path":  "/api/v1/event/feeds/user/" + token + ".ics",
+		},
+	})
+}
@@ -0,0 +1,165 @@
+package event
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/pkg/utils"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// flushEveryRows controls how often CSV rows are pushed to the client while streaming
+const flushEveryRows = 500
+
+// registrationExportWriter opens the response lazily, so errors raised before the first row can still be sent as JSON
+type registrationExportWriter struct {
+	c        *gin.Context
+	format   string
+	filename string
+	csv      *csv.Writer
+	xlsx     *utils.XLSXWriter
+	rows     int
+}
+
+// escapeCSVFormula keeps spreadsheets from evaluating a cell as a formula by prefixing it with a quote.
+// Registrants control names and form answers, a value such as =HYPERLINK(...) must stay plain text.
+func escapeCSVFormula(value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
+
+func (w *registrationExportWriter) start() error {
+	if w.format == "xlsx" {
+		w.c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	} else {
+		w.c.Header("Content-Type", "text/csv; charset=utf-8")
+	}
+	w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, w.filename, w.format))
+	w.c.Status(http.StatusOK)
+
+	if w.format == "xlsx" {
+		xlsx, err := utils.NewXLSXWriter(w.c.Writer, "Registrations")
+		if err != nil {
+			return err
+		}
+		w.xlsx = xlsx
+		return nil
+	}
+
+	// Excel needs the byte order mark to read UTF-8 CSV files correctly
+	if _, err := w.c.Writer.WriteString("\xEF\xBB\xBF"); err != nil {
+		return err
+	}
+	w.csv = csv.NewWriter(w.c.Writer)
+	return nil
+}
+
+func (w *registrationExportWriter) WriteRow(values []string) error {
+	if w.csv == nil && w.xlsx == nil {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	w.rows++
+	if w.xlsx != nil {
+		return w.xlsx.WriteRow(values)
+	}
+
+	// XLSX cells are written as inline strings and never evaluated, only CSV cells need escaping
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeCSVFormula(value)
+	}
+
+	if err := w.csv.Write(escaped); err != nil {
+		return err
+	}
+	if w.rows%flushEveryRows == 0 {
+		w.csv.Flush()
+		w.c.Writer.Flush()
+	}
+	return w.csv.Error()
+}
+
+func (w *registrationExportWriter) Close() error {
+	if w.xlsx != nil {
+		return w.xlsx.Close()
+	}
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+// ExportRegisteredUsers streams the registrants of an event as CSV or XLSX.
+// Query parameters: format (csv or xlsx, default csv) and columns (comma separated, default all columns).
+func (h *Handlers) ExportRegisteredUsers(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:listRegisteredUsers")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "csv"))
+	if format != "csv" && format != "xlsx" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Format must be csv or xlsx"}})
+		return
+	}
+
+	var columns []string
+	for _, column := range strings.Split(c.Query("columns"), ",") {
+		if column = strings.TrimSpace(column); column != "" {
+			columns = append(columns, column)
+		}
+	}
+
+	event, err := h.EventService.GetEventByID(eventID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": []string{"Event not found"}})
+		return
+	}
+
+	writer := &registrationExportWriter{c: c, format: format, filename: event.Slug + "-registrations"}
+	err = h.EventService.ExportRegistrations(c.Request.Context(), eventID, columns, writer)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		if writer.rows > 0 {
+			// The response has already started, the client will receive a truncated file
+			log.Printf("Export of registrations for event %d failed: %v", eventID, err)
+			return
+		}
+
+		var badRequest utils.BadRequestError
+		if errors.As(err, &badRequest) {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+	}
+}
@@ -0,0 +1,30 @@
+package event
+
+import "testing"
+
+func TestEscapeCSVFormula(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"empty", "", ""},
+		{"plain text", "Jane Doe", "Jane Doe"},
+		{"equals", "=HYPERLINK(\"http://evil.example\")", "'=HYPERLINK(\"http://evil.example\")"},
+		{"plus", "+1+cmd|' /C calc'!A0", "'+1+cmd|' /C calc'!A0"},
+		{"minus", "-2+3", "'-2+3"},
+		{"at", "@SUM(A1:A2)", "'@SUM(A1:A2)"},
+		{"tab", "\t=1+1", "'\t=1+1"},
+		{"carriage return", "\r=1+1", "'\r=1+1"},
+		{"formula character later", "Jane =1+1", "Jane =1+1"},
+		{"email", "jane@example.com", "jane@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := escapeCSVFormula(tt.value); got != tt.want {
+				t.Errorf("escapeCSVFormula(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package event
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// GetFeedbackSurvey retrieves the feedback survey of an event
+func (h *Handlers) GetFeedbackSurvey(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	survey, err := h.EventService.GetFeedbackSurvey(eventID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Feedback Survey Retrieved Successfully",
+		"data":    survey,
+	})
+}
+
+// UpdateFeedbackSurvey creates or replaces the feedback survey of an event
+func (h *Handlers) UpdateFeedbackSurvey(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	if _, err := h.EventService.GetEventByID(eventID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": []string{"Event not found"}})
+		return
+	}
+
+	var survey models.EventFeedbackSurvey
+	if err := c.BindJSON(&survey); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+	survey.EventID = eventID
+
+	if err := h.EventService.UpdateFeedbackSurvey(&survey); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Feedback Survey Updated Successfully",
+		"data":    survey,
+	})
+}
+
+// SubmitFeedback stores the feedback of the current user for an event
+func (h *Handlers) SubmitFeedback(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	var feedback models.EventFeedback
+	if err := c.BindJSON(&feedback); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+	feedback.EventID = eventID
+	feedback.UserID = userID
+
+	if err := h.EventService.SubmitFeedback(&feedback); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Feedback Submitted Successfully",
+		"data":    feedback,
+	})
+}
+
+// GetFeedbackResults retrieves the aggregated feedback of an event for its organizers
+func (h *Handlers) GetFeedbackResults(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	results, err := h.EventService.GetFeedbackResults(eventID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Feedback Results Retrieved Successfully",
+		"data":    results,
+	})
+}
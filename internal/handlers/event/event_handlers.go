@@ -8,10 +8,13 @@ import (
 	"Backend/pkg/utils"
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"io"
 	"log"
 	"net/http"
+	"os"
 	"strconv"
 )
 
@@ -61,7 +64,11 @@ func (h *Handlers) CreateEvent(c *gin.Context) {
 	newEvent.UserID = userID
 
 	if newEvent.Title != "" {
-		newEvent.Slug = utils.GenerateFriendlyURL(newEvent.Title)
+		newEvent.Slug, err = h.EventService.GenerateEventSlug(newEvent.Title, 0)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+			return
+		}
 	}
 
 	if newEvent.StartDate.After(newEvent.EndDate) {
@@ -99,7 +106,7 @@ func (h *Handlers) CreateEvent(c *gin.Context) {
 	newEvent.Thumbnail, _ = h.R2Service.GetFileR2("event", newEvent.Slug)
 
 	if err := h.EventService.CreateEvent(&newEvent); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
 		return
 	}
 
@@ -132,6 +139,10 @@ func (h *Handlers) EditEvent(c *gin.Context) {
 		return
 	}
 
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
 	// Get existing event first
 	existingEvent, err := h.EventService.GetEventByID(eventID)
 	if err != nil {
@@ -167,7 +178,11 @@ func (h *Handlers) EditEvent(c *gin.Context) {
 
 	// Set slug based on title change
 	if updatedEvent.Title != "" && updatedEvent.Title != existingEvent.Title {
-		updatedEvent.Slug = utils.GenerateFriendlyURL(updatedEvent.Title)
+		updatedEvent.Slug, err = h.EventService.GenerateEventSlug(updatedEvent.Title, eventID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+			return
+		}
 	} else {
 		updatedEvent.Slug = existingEvent.Slug
 	}
@@ -223,10 +238,37 @@ func (h *Handlers) EditEvent(c *gin.Context) {
 		updatedEvent.Thumbnail = existingEvent.Thumbnail
 	}
 
+	// The status only changes through status transitions
+	updatedEvent.Status = ""
+
 	utils.ReflectiveUpdate(existingEvent, updatedEvent)
 
-	if err := h.EventService.EditEvent(eventID, existingEvent); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+	// ReflectiveUpdate skips false values, so booleans sent explicitly are applied here
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &fields); err == nil {
+		if _, ok := fields["open_for_all"]; ok {
+			existingEvent.OpenForAll = updatedEvent.OpenForAll
+		}
+		if _, ok := fields["team_registration"]; ok {
+			existingEvent.TeamRegistration = updatedEvent.TeamRegistration
+		}
+		if _, ok := fields["all_day"]; ok {
+			existingEvent.AllDay = updatedEvent.AllDay
+		}
+		// Venue and meeting link can be removed by sending null
+		if _, ok := fields["venue"]; ok {
+			existingEvent.Venue = updatedEvent.Venue
+		}
+		if _, ok := fields["online_meeting_url"]; ok {
+			existingEvent.OnlineMeetingURL = updatedEvent.OnlineMeetingURL
+		}
+	}
+
+	// Occurrences of a series are edited alone unless scope=future is given
+	scope := c.DefaultQuery("scope", services.EditScopeThisOccurrence)
+
+	if err := h.EventService.EditEvent(eventID, existingEvent, scope); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
 		return
 	}
 
@@ -243,7 +285,7 @@ func (h *Handlers) EditEvent(c *gin.Context) {
 }
 
 func (h *Handlers) DeleteEvent(c *gin.Context) {
-	_, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:delete")
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:delete")
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
 		return
@@ -256,6 +298,10 @@ func (h *Handlers) DeleteEvent(c *gin.Context) {
 		return
 	}
 
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
 	event, err := h.EventService.GetEventByID(eventID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
@@ -314,44 +360,70 @@ func (h *Handlers) GetEventByID(c *gin.Context) {
 	})
 }
 
-// GetEventBySlug retrieves an event by its slug
+// GetEventBySlug retrieves an event by its slug. An event found by a slug it had before being renamed
+// comes with redirect set so the frontend can move to canonicalSlug.
 func (h *Handlers) GetEventBySlug(c *gin.Context) {
 	slug := c.Param("eventID")
 
-	event, err := h.EventService.GetEventBySlug(slug)
+	event, err := h.EventService.GetEventBySlug(slug, viewerID(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Event Retrieved Successfully",
-		"data":    event,
+		"success":       true,
+		"message":       "Event Retrieved Successfully",
+		"data":          event,
+		"canonicalSlug": event.Slug,
+		"redirect":      event.Slug != slug,
 	})
 
 }
 
+// viewerID returns the ID of the signed in user, or uuid.Nil for visitors of the public event routes
+func viewerID(c *gin.Context) uuid.UUID {
+	token, err := utils.ExtractTokenFromHeader(c)
+	if err != nil {
+		return uuid.Nil
+	}
+
+	userID, err := utils.GetUserIDFromToken(token, os.Getenv("JWT_SECRET_KEY"))
+	if err != nil {
+		return uuid.Nil
+	}
+	return userID
+}
+
 // ListEvents retrieves a list of events based on the query parameters
 func (h *Handlers) ListEvents(c *gin.Context) {
 	log.Println("List Events Begin")
 
 	queryParams := map[string]string{
+		"search":          c.Query("search"),
 		"organization_id": c.Query("organization_id"),
 		"status":          c.Query("status"),
+		"from":            c.Query("from"),
+		"to":              c.Query("to"),
+		"open_for_all":    c.Query("open_for_all"),
+		"has_capacity":    c.Query("has_capacity"),
+		"sort":            c.Query("sort"),
+		"limit":           c.Query("limit"),
+		"cursor":          c.Query("cursor"),
 		"page":            c.Query("page"),
 	}
 
-	events, totalPages, err := h.EventService.ListEvents(queryParams)
+	list, err := h.EventService.ListEvents(queryParams)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"success":      true,
-		"data":         events,
-		"totalResults": len(events),
-		"totalPages":   totalPages,
+		"data":         list.Events,
+		"totalResults": list.Total,
+		"totalPages":   list.TotalPages,
+		"nextCursor":   list.NextCursor,
 	})
 }
 
@@ -394,8 +466,18 @@ func (h *Handlers) RegisterForEvent(c *gin.Context) {
 
 	log.Println("Register for Event Middle 2")
 
-	if err := h.EventService.RegisterForEvent(userID, eventID, eventRegistration.AdditionalNotes); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+	if err := h.EventService.RegisterForEvent(userID, eventID, eventRegistration.AdditionalNotes, eventRegistration.FormAnswers); err != nil {
+		var notEligible services.NotEligibleError
+		if errors.As(err, &notEligible) {
+			c.JSON(http.StatusForbidden, gin.H{"success": false, "message": []string{err.Error()}, "reasons": notEligible.Reasons})
+			return
+		}
+		var teamRequired utils.TeamRegistrationRequiredError
+		if errors.As(err, &teamRequired) {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+			return
+		}
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
 		return
 	}
 
@@ -433,6 +515,10 @@ func (h *Handlers) ListRegisteredUsers(c *gin.Context) {
 		return
 	}
 
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
 	users, err := h.EventService.ListRegisteredUsers(eventID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
@@ -498,3 +584,175 @@ func (h *Handlers) TotalRegisteredUsers(c *gin.Context) {
 		"data":    total,
 	})
 }
+
+// GetEligibility retrieves the eligibility rules of an event
+func (h *Handlers) GetEligibility(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	eligibility, err := h.EventService.GetEligibility(eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Eligibility Rules Retrieved Successfully",
+		"data":    eligibility,
+	})
+}
+
+// UpdateEligibility replaces the eligibility rules of an event
+func (h *Handlers) UpdateEligibility(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	if _, err := h.EventService.GetEventByID(eventID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": []string{"Event not found"}})
+		return
+	}
+
+	var eligibility models.EventEligibility
+	if err := c.BindJSON(&eligibility); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+	eligibility.EventID = eventID
+
+	if err := h.EventService.UpdateEligibility(&eligibility); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Eligibility Rules Updated Successfully",
+		"data":    eligibility,
+	})
+}
+
+// CheckEligibility tells the current user whether they may register for an event
+func (h *Handlers) CheckEligibility(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	result, err := h.EventService.CheckEligibility(eventID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Eligibility Checked Successfully",
+		"data":    result,
+	})
+}
+
+// CheckInRegistration marks a registered user as present at the event
+func (h *Handlers) CheckInRegistration(c *gin.Context) {
+	managerID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, managerID) {
+		return
+	}
+
+	userID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid User ID"}})
+		return
+	}
+
+	registration, err := h.EventService.CheckInRegistration(eventID, userID)
+	if err != nil {
+		var notFound *utils.NotFoundError
+		if errors.As(err, &notFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": []string{err.Error()}})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "User Checked In Successfully",
+		"data":    registration,
+	})
+}
+
+// TransitionEventStatus moves an event to another status, the request body is {"status": "cancelled", "reason": "..."}
+func (h *Handlers) TransitionEventStatus(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	var request struct {
+		Status models.EventStatus `json:"status"`
+		Reason string             `json:"reason"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	event, err := h.EventService.TransitionEventStatus(eventID, request.Status, request.Reason)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Status Updated Successfully",
+		"data":    event,
+	})
+}
@@ -0,0 +1,82 @@
+package event
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+// requireEventManager responds with an error and returns false unless the user may manage the event
+func (h *Handlers) requireEventManager(c *gin.Context, eventID int, userID uuid.UUID) bool {
+	if err := h.EventService.RequireEventManager(eventID, userID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return false
+	}
+	return true
+}
+
+// GetEventHosts retrieves the organizations hosting an event
+func (h *Handlers) GetEventHosts(c *gin.Context) {
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	hosts, err := h.EventService.GetEventHosts(eventID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Hosts Retrieved Successfully",
+		"data":    hosts,
+	})
+}
+
+// UpdateEventHosts replaces the primary host and the co-hosts of an event
+func (h *Handlers) UpdateEventHosts(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	var update models.EventHostsUpdate
+	if err := c.BindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := h.EventService.UpdateEventHosts(eventID, &update); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	hosts, err := h.EventService.GetEventHosts(eventID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Hosts Updated Successfully",
+		"data":    hosts,
+	})
+}
@@ -0,0 +1,56 @@
+package event
+
+import (
+	"Backend/internal/handlers/auth"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// GetEventReminderPreference tells whether the current user receives event reminders
+func (h *Handlers) GetEventReminderPreference(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "users:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	enabled, err := h.EventService.GetEventReminderPreference(userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Reminder Preference Retrieved Successfully",
+		"data":    gin.H{"enabled": enabled},
+	})
+}
+
+// UpdateEventReminderPreference turns event reminders on or off for the current user
+func (h *Handlers) UpdateEventReminderPreference(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "users:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	var request struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.Enabled == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"enabled must be true or false"}})
+		return
+	}
+
+	if err := h.EventService.SetEventReminderPreference(userID, *request.Enabled); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Reminder Preference Updated Successfully",
+		"data":    gin.H{"enabled": *request.Enabled},
+	})
+}
@@ -0,0 +1,184 @@
+package event
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// CreateEventSeries creates a recurring event. The multipart "data" field holds the event of the
+// first occurrence plus "recurrence_rule" (e.g. FREQ=WEEKLY;BYDAY=TU;COUNT=10) and optional "exceptions" dates.
+func (h *Handlers) CreateEventSeries(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:create")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := c.Request.ParseMultipartForm(10 << 20); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	var request struct {
+		models.Event
+		RecurrenceRule string   `json:"recurrence_rule"`
+		Exceptions     []string `json:"exceptions"`
+	}
+	if err := json.Unmarshal([]byte(c.Request.FormValue("data")), &request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	template := request.Event
+	template.UserID = userID
+
+	if template.Title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Title is required"}})
+		return
+	}
+
+	if template.StartDate.After(template.EndDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Start Date cannot be after End Date"}})
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "No file uploaded"})
+		return
+	}
+
+	optimizedImage, err := utils.OptimizeImage(file, 2800, 1080)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	optimizedImageBytes, err := io.ReadAll(optimizedImage)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	// All occurrences share the thumbnail of the series
+	thumbnailKey := utils.GenerateFriendlyURL(template.Title) + "-series"
+	if err := h.R2Service.UploadFileToR2(context.Background(), "event", thumbnailKey, optimizedImageBytes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	template.Thumbnail, _ = h.R2Service.GetFileR2("event", thumbnailKey)
+
+	series, err := h.EventService.CreateEventSeries(&template, request.RecurrenceRule, request.Exceptions)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Event Series Created Successfully",
+		"data":    series,
+		"relationships": gin.H{
+			"author": gin.H{
+				"id": userID,
+			},
+		},
+	})
+}
+
+// GetEventSeries retrieves a series with its occurrences
+func (h *Handlers) GetEventSeries(c *gin.Context) {
+	seriesID, err := strconv.Atoi(c.Param("seriesID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Series ID"}})
+		return
+	}
+
+	series, err := h.EventService.GetEventSeries(seriesID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Series Retrieved Successfully",
+		"data":    series,
+	})
+}
+
+// AddSeriesException skips a date of a series, the request body is {"date": "YYYY-MM-DD"}
+func (h *Handlers) AddSeriesException(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:edit")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	seriesID, err := strconv.Atoi(c.Param("seriesID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Series ID"}})
+		return
+	}
+
+	if err := h.EventService.RequireSeriesManager(seriesID, userID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	var request struct {
+		Date string `json:"date"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := h.EventService.AddSeriesException(seriesID, request.Date); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Series Exception Added Successfully",
+	})
+}
+
+// DeleteEventSeries deletes a series and its upcoming occurrences, refused while one of them has registrations
+func (h *Handlers) DeleteEventSeries(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:delete")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	seriesID, err := strconv.Atoi(c.Param("seriesID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Series ID"}})
+		return
+	}
+
+	if err := h.EventService.RequireSeriesManager(seriesID, userID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := h.EventService.DeleteEventSeries(seriesID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Series Deleted Successfully",
+	})
+}
@@ -0,0 +1,200 @@
+package event
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// DuplicateEvent creates a draft copy of an event, the request body is {"title": "...", "start_date": "2025-09-01"}
+// or {"shift_days": 182}
+func (h *Handlers) DuplicateEvent(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:create")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	var request models.EventCopyRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	event, err := h.EventService.DuplicateEvent(c.Request.Context(), eventID, userID, &request)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Event Duplicated Successfully",
+		"data":    event,
+	})
+}
+
+// SaveEventTemplate saves an event as a named template, the request body is {"name": "..."}
+func (h *Handlers) SaveEventTemplate(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:create")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	if !h.requireEventManager(c, eventID, userID) {
+		return
+	}
+
+	var request struct {
+		Name string `json:"name"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	template, err := h.EventService.SaveEventTemplate(c.Request.Context(), eventID, userID, request.Name)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Event Template Saved Successfully",
+		"data":    template,
+	})
+}
+
+// ListEventTemplates retrieves the event templates, optionally of a single organization
+func (h *Handlers) ListEventTemplates(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:create"); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	organizationID := 0
+	if value := c.Query("organization_id"); value != "" {
+		var err error
+		organizationID, err = strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Organization ID"}})
+			return
+		}
+	}
+
+	templates, err := h.EventService.ListEventTemplates(organizationID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Templates Retrieved Successfully",
+		"data":    templates,
+	})
+}
+
+// GetEventTemplate retrieves an event template
+func (h *Handlers) GetEventTemplate(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:create"); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("templateID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Template ID"}})
+		return
+	}
+
+	template, err := h.EventService.GetEventTemplate(templateID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Template Retrieved Successfully",
+		"data":    template,
+	})
+}
+
+// CreateEventFromTemplate creates a draft event from a template, the request body is {"title": "...", "start_date": "2025-09-01"}
+func (h *Handlers) CreateEventFromTemplate(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:create")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("templateID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Template ID"}})
+		return
+	}
+
+	var request models.EventCopyRequest
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	event, err := h.EventService.CreateEventFromTemplate(c.Request.Context(), templateID, userID, &request)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Event Created Successfully",
+		"data":    event,
+	})
+}
+
+// DeleteEventTemplate deletes an event template
+func (h *Handlers) DeleteEventTemplate(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:delete")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	templateID, err := strconv.Atoi(c.Param("templateID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Template ID"}})
+		return
+	}
+
+	if err := h.EventService.DeleteEventTemplate(c.Request.Context(), templateID, userID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Event Template Deleted Successfully",
+	})
+}
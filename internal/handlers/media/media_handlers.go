@@ -0,0 +1,68 @@
+package media
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/services"
+	"Backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+)
+
+type Handlers struct {
+	MediaService      *services.MediaService
+	PermissionService *services.PermissionService
+}
+
+func NewMediaHandlers(mediaService *services.MediaService, permissionService *services.PermissionService) *Handlers {
+	return &Handlers{
+		MediaService:      mediaService,
+		PermissionService: permissionService,
+	}
+}
+
+// UploadMedia stores an image to embed in the content of a news or an event, it is converted to JPEG.
+// The URL returned is meant to be put in the content, the image is removed some time after no content uses it.
+func (h *Handlers) UploadMedia(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "media:upload")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := c.Request.ParseMultipartForm(10 << 20); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"No image provided"}})
+		return
+	}
+	defer file.Close()
+
+	optimizedImage, err := utils.OptimizeImage(file, 1920, 1920)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"The file is not a supported image"}})
+		return
+	}
+
+	optimizedImageBytes, err := io.ReadAll(optimizedImage)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	media, err := h.MediaService.UploadMedia(c.Request.Context(), userID, optimizedImageBytes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Media Uploaded Successfully",
+		"data":    media,
+	})
+}
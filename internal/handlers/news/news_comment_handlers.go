@@ -0,0 +1,241 @@
+package news
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// ListNewsComments retrieves the comment threads of a published news
+func (h *Handler) ListNewsComments(c *gin.Context) {
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	comments, err := h.NewsService.ListNewsComments(newsID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Comments Retrieved Successfully",
+		"data":    comments,
+	})
+}
+
+// CreateNewsComment comments on a news, the request body is {"content": "...", "parent_id": 12} where
+// parent_id is only given for replies
+func (h *Handler) CreateNewsComment(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:comment")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	var input models.NewsCommentInput
+	if err := c.BindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	comment, err := h.NewsService.CreateNewsComment(newsID, userID, &input)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Comment Created Successfully",
+		"data":    comment,
+	})
+}
+
+// EditNewsComment replaces the content of a comment of the user, the request body is {"content": "..."}
+func (h *Handler) EditNewsComment(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:comment")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Comment ID"}})
+		return
+	}
+
+	var input models.NewsCommentInput
+	if err := c.BindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	comment, err := h.NewsService.EditNewsComment(commentID, userID, &input)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Comment Updated Successfully",
+		"data":    comment,
+	})
+}
+
+// DeleteNewsComment deletes a comment of the user, moderators may delete any comment
+func (h *Handler) DeleteNewsComment(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:comment")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Comment ID"}})
+		return
+	}
+
+	moderator, err := h.PermissionService.CheckPermission(c.Request.Context(), userID, "news:moderate")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := h.NewsService.DeleteNewsComment(commentID, userID, moderator); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Comment Deleted Successfully",
+	})
+}
+
+// SetNewsCommentVisibility hides a comment from readers or shows it again, the request body is {"hidden": true}
+func (h *Handler) SetNewsCommentVisibility(c *gin.Context) {
+	moderatorID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:moderate")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Comment ID"}})
+		return
+	}
+
+	var request struct {
+		Hidden bool `json:"hidden"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	comment, err := h.NewsService.SetNewsCommentHidden(commentID, request.Hidden, moderatorID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Comment Visibility Updated Successfully",
+		"data":    comment,
+	})
+}
+
+// ReportNewsComment reports a comment to the moderators, the request body is {"reason": "..."}
+func (h *Handler) ReportNewsComment(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:comment")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Comment ID"}})
+		return
+	}
+
+	var request struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := h.NewsService.ReportNewsComment(commentID, userID, request.Reason); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Comment Reported Successfully",
+	})
+}
+
+// ListReportedNewsComments retrieves the moderation queue of reported comments
+func (h *Handler) ListReportedNewsComments(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:moderate"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	queue, err := h.NewsService.ListReportedNewsComments()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Reported Comments Retrieved Successfully",
+		"data":    queue,
+	})
+}
+
+// DismissNewsCommentReports closes the open reports of a comment and keeps it visible
+func (h *Handler) DismissNewsCommentReports(c *gin.Context) {
+	moderatorID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:moderate")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Comment ID"}})
+		return
+	}
+
+	if err := h.NewsService.DismissNewsCommentReports(commentID, moderatorID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Comment Reports Dismissed Successfully",
+	})
+}
@@ -0,0 +1,31 @@
+package news
+
+import (
+	"Backend/pkg/utils"
+	"errors"
+	"net/http"
+)
+
+// errorStatus maps news errors to their HTTP status code
+func errorStatus(err error) int {
+	var badRequest utils.BadRequestError
+	var unauthorized utils.UnauthorizedError
+	var notFound *utils.NotFoundError
+	var conflict *utils.ConflictError
+	var tooManyRequests *utils.TooManyRequestsError
+
+	switch {
+	case errors.As(err, &badRequest):
+		return http.StatusBadRequest
+	case errors.As(err, &unauthorized):
+		return http.StatusForbidden
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	case errors.As(err, &conflict):
+		return http.StatusConflict
+	case errors.As(err, &tooManyRequests):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
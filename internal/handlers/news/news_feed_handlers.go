@@ -0,0 +1,100 @@
+package news
+
+import (
+	"Backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	rssContentType  = "application/rss+xml; charset=utf-8"
+	atomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// writeNewsFeed renders the news feed of an organization, 0 for all news, as RSS or Atom.
+// Feed readers poll, so unchanged feeds are answered with 304 through ETag and Last-Modified.
+func (h *Handler) writeNewsFeed(c *gin.Context, organizationID int, atom bool) {
+	format := "rss"
+	if atom {
+		format = "atom"
+	}
+
+	feed, err := h.NewsService.NewsFeed(organizationID, format)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	contentType := rssContentType
+	var body []byte
+	if atom {
+		contentType = atomContentType
+		body, err = utils.BuildAtom(feed)
+	} else {
+		body, err = utils.BuildRSS(feed)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	etag := utils.FeedETag(body)
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "public, max-age=900")
+	if !feed.Updated.IsZero() {
+		c.Header("Last-Modified", feed.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	// If-None-Match takes precedence over If-Modified-Since when both are sent
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				c.Status(http.StatusNotModified)
+				return
+			}
+		}
+	} else if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !feed.Updated.IsZero() {
+		if !feed.Updated.Truncate(time.Second).After(since) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// NewsRSSFeed is the RSS 2.0 feed of the latest published news
+func (h *Handler) NewsRSSFeed(c *gin.Context) {
+	h.writeNewsFeed(c, 0, false)
+}
+
+// NewsAtomFeed is the Atom feed of the latest published news
+func (h *Handler) NewsAtomFeed(c *gin.Context) {
+	h.writeNewsFeed(c, 0, true)
+}
+
+// OrganizationNewsRSSFeed is the RSS 2.0 feed of the latest published news of one organization
+func (h *Handler) OrganizationNewsRSSFeed(c *gin.Context) {
+	organizationID, err := strconv.Atoi(c.Param("organizationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Organization ID"}})
+		return
+	}
+
+	h.writeNewsFeed(c, organizationID, false)
+}
+
+// OrganizationNewsAtomFeed is the Atom feed of the latest published news of one organization
+func (h *Handler) OrganizationNewsAtomFeed(c *gin.Context) {
+	organizationID, err := strconv.Atoi(c.Param("organizationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Organization ID"}})
+		return
+	}
+
+	h.writeNewsFeed(c, organizationID, true)
+}
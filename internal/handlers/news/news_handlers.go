@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"io"
 	"log"
 	"net/http"
@@ -54,7 +55,11 @@ func (h *Handler) CreateNews(c *gin.Context) {
 	newNews.UserID = userID
 
 	if newNews.Title != "" {
-		newNews.Slug = utils.GenerateFriendlyURL(newNews.Title)
+		newNews.Slug, err = h.NewsService.GenerateNewsSlug(newNews.Title, 0)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+			return
+		}
 	}
 
 	if newNews.PublishDate.IsZero() {
@@ -101,7 +106,7 @@ func (h *Handler) CreateNews(c *gin.Context) {
 	}
 
 	if err := h.NewsService.CreateNews(&newNews); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
 		return
 	}
 
@@ -136,22 +141,25 @@ func (h *Handler) GetNewsByID(c *gin.Context) {
 func (h *Handler) GetNewsBySlug(c *gin.Context) {
 	newsSlug := c.Param("newsID")
 
-	news, err := h.NewsService.GetNewsBySlug(newsSlug)
+	news, err := h.NewsService.GetNewsBySlug(newsSlug, viewerID(c), c.ClientIP())
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": []string{"News not found"}})
 		return
 	}
 
+	// Slugs of renamed news still resolve, redirect tells the frontend to move to canonicalSlug
 	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "News Retrieved Successfully",
-		"data":    news,
+		"success":       true,
+		"message":       "News Retrieved Successfully",
+		"data":          news,
+		"canonicalSlug": news.Slug,
+		"redirect":      news.Slug != newsSlug,
 	})
 
 }
 
 func (h *Handler) EditNews(c *gin.Context) {
-	_, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit")
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit")
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
 		return
@@ -195,7 +203,11 @@ func (h *Handler) EditNews(c *gin.Context) {
 
 	// Handle slug generation
 	if updatedNews.Title != "" && updatedNews.Title != existingNews.Title {
-		updatedNews.Slug = utils.GenerateFriendlyURL(updatedNews.Title)
+		updatedNews.Slug, err = h.NewsService.GenerateNewsSlug(updatedNews.Title, newsID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+			return
+		}
 		log.Printf("Generated new slug: %s", updatedNews.Slug)
 	} else {
 		updatedNews.Slug = existingNews.Slug
@@ -255,9 +267,9 @@ func (h *Handler) EditNews(c *gin.Context) {
 	// Update the news in the database
 	utils.ReflectiveUpdate(existingNews, &updatedNews)
 
-	if err := h.NewsService.EditNews(newsID, &updatedNews); err != nil {
+	if err := h.NewsService.EditNews(newsID, userID, &updatedNews); err != nil {
 		log.Printf("Error updating news in database: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
 		return
 	}
 
@@ -265,7 +277,7 @@ func (h *Handler) EditNews(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "News Updated Successfully",
-		"data":    existingNews,
+		"data":    updatedNews,
 	})
 }
 
@@ -323,8 +335,10 @@ func (h *Handler) ListNews(c *gin.Context) {
 	queryParams := make(map[string]string)
 	queryParams["organization_id"] = c.Query("organization_id")
 	queryParams["page"] = c.Query("page")
+	queryParams["tags"] = c.Query("tags")
+	queryParams["tag_match"] = c.Query("tag_match")
 
-	news, totalPages, err := h.NewsService.ListNews(queryParams)
+	news, totalPages, err := h.NewsService.ListNews(queryParams, viewerID(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
 		return
@@ -338,6 +352,21 @@ func (h *Handler) ListNews(c *gin.Context) {
 	})
 }
 
+// viewerID returns the ID of the signed in user, or uuid.Nil for visitors of the public news routes
+func viewerID(c *gin.Context) uuid.UUID {
+	token, err := utils.ExtractTokenFromHeader(c)
+	if err != nil {
+		return uuid.Nil
+	}
+
+	userID, err := utils.GetUserIDFromToken(token, os.Getenv("JWT_SECRET_KEY"))
+	if err != nil {
+		return uuid.Nil
+	}
+	return userID
+}
+
+// LikeNews likes a news, liking a news already liked succeeds without counting it twice
 func (h *Handler) LikeNews(c *gin.Context) {
 	token, err := utils.ExtractTokenFromHeader(c)
 	if err != nil {
@@ -357,43 +386,48 @@ func (h *Handler) LikeNews(c *gin.Context) {
 		return
 	}
 
-	if err := h.NewsService.LikeNews(userID, newsID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+	likes, err := h.NewsService.LikeNews(userID, newsID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"message": "News Liked Successfully",
+		"data":    gin.H{"likes": likes, "liked_by_me": true},
 	})
 }
 
-//func (h *Handler) UnlikeNews(c *gin.Context) {
-//	token, err := utils.ExtractTokenFromHeader(c)
-//	if err != nil {
-//		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
-//		return
-//	}
-//	userID, err := utils.GetUserIDFromToken(token, os.Getenv("JWT_SECRET_KEY"))
-//	if err != nil {
-//		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
-//		return
-//	}
-//
-//	newsIDStr := c.Param("newsID")
-//	newsID, err := strconv.Atoi(newsIDStr)
-//	if err != nil {
-//		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
-//		return
-//	}
-//
-//	if err := h.NewsService.UnlikeNews(userID, newsID); err != nil {
-//		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
-//		return
-//	}
-//
-//	c.JSON(http.StatusOK, gin.H{
-//		"success": true,
-//		"message": "News Unliked Successfully",
-//	})
-//}
+// UnlikeNews removes the like of the user, unliking a news that is not liked succeeds as well
+func (h *Handler) UnlikeNews(c *gin.Context) {
+	token, err := utils.ExtractTokenFromHeader(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+	userID, err := utils.GetUserIDFromToken(token, os.Getenv("JWT_SECRET_KEY"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsIDStr := c.Param("newsID")
+	newsID, err := strconv.Atoi(newsIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	likes, err := h.NewsService.UnlikeNews(userID, newsID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "News Unliked Successfully",
+		"data":    gin.H{"likes": likes, "liked_by_me": false},
+	})
+}
@@ -0,0 +1,132 @@
+package news
+
+import (
+	"Backend/internal/handlers/auth"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// ListNewsRevisions lists the revisions of a news, latest first and without their content
+func (h *Handler) ListNewsRevisions(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	revisions, err := h.NewsService.ListNewsRevisions(newsID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "News Revisions Retrieved Successfully",
+		"data":    revisions,
+	})
+}
+
+// GetNewsRevision retrieves a revision of a news with its content
+func (h *Handler) GetNewsRevision(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	revisionNumber, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Revision"}})
+		return
+	}
+
+	revision, err := h.NewsService.GetNewsRevision(newsID, revisionNumber)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "News Revision Retrieved Successfully",
+		"data":    revision,
+	})
+}
+
+// DiffNewsRevisions compares two revisions of a news given as ?from=2&to=5
+func (h *Handler) DiffNewsRevisions(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	from, fromErr := strconv.Atoi(c.Query("from"))
+	to, toErr := strconv.Atoi(c.Query("to"))
+	if fromErr != nil || toErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"from and to must be revision numbers"}})
+		return
+	}
+
+	diff, err := h.NewsService.DiffNewsRevisions(newsID, from, to)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "News Revisions Compared Successfully",
+		"data":    diff,
+	})
+}
+
+// RestoreNewsRevision brings a news back to one of its revisions, the rollback is itself a new revision
+func (h *Handler) RestoreNewsRevision(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit")
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	revision, err := strconv.Atoi(c.Param("revision"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Revision"}})
+		return
+	}
+
+	news, err := h.NewsService.RestoreNewsRevision(c.Request.Context(), newsID, revision, userID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "News Revision Restored Successfully",
+		"data":    news,
+	})
+}
@@ -0,0 +1,68 @@
+package news
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// UpdateNewsStatus publishes, schedules, unpublishes or archives a news,
+// the request body is {"status": "scheduled", "publish_date": "2025-09-01T08:00:00Z"}
+func (h *Handler) UpdateNewsStatus(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	var update models.NewsStatusUpdate
+	if err := c.BindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	news, err := h.NewsService.UpdateNewsStatus(newsID, &update)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "News Status Updated Successfully",
+		"data":    news,
+	})
+}
+
+// PreviewNews retrieves a news by its ID whatever its status, so authors can review it before it goes live
+func (h *Handler) PreviewNews(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:create"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	news, err := h.NewsService.PreviewNews(newsID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "News Retrieved Successfully",
+		"data":    news,
+	})
+}
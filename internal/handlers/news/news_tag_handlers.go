@@ -0,0 +1,153 @@
+package news
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// ListNewsTags retrieves the tags and categories, ?kind=tag or ?kind=category returns only one of them
+func (h *Handler) ListNewsTags(c *gin.Context) {
+	tags, err := h.NewsService.ListNewsTags(c.Query("kind"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Tags Retrieved Successfully",
+		"data":    tags,
+	})
+}
+
+// NewsTagCloud retrieves the most used tags with their number of published news
+func (h *Handler) NewsTagCloud(c *gin.Context) {
+	tags, err := h.NewsService.NewsTagCloud(c.Query("kind"), c.Query("limit"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Tag Cloud Retrieved Successfully",
+		"data":    tags,
+	})
+}
+
+// CreateNewsTag creates a tag or a category, the request body is {"name": "...", "kind": "category", "description": "..."}
+func (h *Handler) CreateNewsTag(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	var tag models.NewsTag
+	if err := c.BindJSON(&tag); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := h.NewsService.CreateNewsTag(&tag); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Tag Created Successfully",
+		"data":    tag,
+	})
+}
+
+// UpdateNewsTag renames a tag or changes its kind or description
+func (h *Handler) UpdateNewsTag(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	tagID, err := strconv.Atoi(c.Param("tagID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Tag ID"}})
+		return
+	}
+
+	var tag models.NewsTag
+	if err := c.BindJSON(&tag); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	if err := h.NewsService.UpdateNewsTag(tagID, &tag); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Tag Updated Successfully",
+		"data":    tag,
+	})
+}
+
+// DeleteNewsTag deletes a tag, the news filed under it keep their other tags
+func (h *Handler) DeleteNewsTag(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	tagID, err := strconv.Atoi(c.Param("tagID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Tag ID"}})
+		return
+	}
+
+	if err := h.NewsService.DeleteNewsTag(tagID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Tag Deleted Successfully",
+	})
+}
+
+// SetNewsTags replaces the tags of a news, the request body is {"tag_ids": [1, 4]}
+func (h *Handler) SetNewsTags(c *gin.Context) {
+	if _, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "news:edit"); err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newsID, err := strconv.Atoi(c.Param("newsID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid News ID"}})
+		return
+	}
+
+	var request struct {
+		TagIDs []int `json:"tag_ids"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	tags, err := h.NewsService.SetNewsTags(newsID, request.TagIDs)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "News Tags Updated Successfully",
+		"data":    tags,
+	})
+}
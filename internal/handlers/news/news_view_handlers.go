@@ -0,0 +1,29 @@
+package news
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// ListTrendingNews lists the published news most viewed and liked lately, ?limit= defaults to 10
+func (h *Handler) ListTrendingNews(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid limit"}})
+		return
+	}
+
+	news, err := h.NewsService.ListTrendingNews(limit, viewerID(c))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"message":      "Trending News Retrieved Successfully",
+		"data":         news,
+		"totalResults": len(news),
+	})
+}
@@ -0,0 +1,49 @@
+package search
+
+import (
+	"Backend/internal/services"
+	"Backend/pkg/utils"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type Handlers struct {
+	SearchService *services.SearchService
+}
+
+func NewSearchHandlers(searchService *services.SearchService) *Handlers {
+	return &Handlers{SearchService: searchService}
+}
+
+// Search looks up news, events and aspirations, as in /search?q=career+fair&type=news,event&page=2
+func (h *Handlers) Search(c *gin.Context) {
+	page := 1
+	if c.Query("page") != "" {
+		var err error
+		if page, err = strconv.Atoi(c.Query("page")); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid page"}})
+			return
+		}
+	}
+
+	results, total, totalPages, err := h.SearchService.Search(c.Query("q"), c.Query("type"), page)
+	if err != nil {
+		status := http.StatusInternalServerError
+		var badRequest utils.BadRequestError
+		if errors.As(err, &badRequest) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"message":      "Search Results Retrieved Successfully",
+		"data":         results,
+		"totalResults": total,
+		"totalPages":   totalPages,
+	})
+}
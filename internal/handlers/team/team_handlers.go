@@ -0,0 +1,347 @@
+package team
+
+import (
+	"Backend/internal/handlers/auth"
+	"Backend/internal/models"
+	"Backend/internal/services"
+	"Backend/pkg/utils"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+type Handlers struct {
+	TeamService       *services.TeamService
+	PermissionService *services.PermissionService
+}
+
+func NewTeamHandlers(teamService *services.TeamService, permissionService *services.PermissionService) *Handlers {
+	return &Handlers{
+		TeamService:       teamService,
+		PermissionService: permissionService,
+	}
+}
+
+// respondError writes a team error with its HTTP status code, eligibility errors include their reasons
+func respondError(c *gin.Context, err error) {
+	var notEligible services.NotEligibleError
+	if errors.As(err, &notEligible) {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": []string{err.Error()}, "reasons": notEligible.Reasons})
+		return
+	}
+
+	c.JSON(errorStatus(err), gin.H{"success": false, "message": []string{err.Error()}})
+}
+
+// errorStatus maps team errors to their HTTP status code
+func errorStatus(err error) int {
+	var badRequest utils.BadRequestError
+	var unauthorized utils.UnauthorizedError
+	var notFound *utils.NotFoundError
+	var conflict *utils.ConflictError
+	var maxReached utils.MaxRegistrationReachedError
+
+	switch {
+	case errors.As(err, &badRequest):
+		return http.StatusBadRequest
+	case errors.As(err, &unauthorized):
+		return http.StatusForbidden
+	case errors.As(err, &notFound):
+		return http.StatusNotFound
+	case errors.As(err, &conflict), errors.As(err, &maxReached):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// parseEventAndTeamID reads the eventID and teamID route parameters
+func parseEventAndTeamID(c *gin.Context) (int, int, bool) {
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return 0, 0, false
+	}
+
+	teamID, err := strconv.Atoi(c.Param("teamID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Team ID"}})
+		return 0, 0, false
+	}
+
+	return eventID, teamID, true
+}
+
+func (h *Handlers) CreateTeam(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	var newTeam models.EventTeam
+	if err := c.BindJSON(&newTeam); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	newTeam.EventID = eventID
+	newTeam.CaptainID = userID
+
+	if err := h.TeamService.CreateTeam(&newTeam); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Team Created Successfully",
+		"data":    newTeam,
+	})
+}
+
+func (h *Handlers) ListTeams(c *gin.Context) {
+	_, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:listRegisteredUsers")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	teams, err := h.TeamService.ListTeamsByEvent(eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":      true,
+		"message":      "Teams Retrieved Successfully",
+		"data":         teams,
+		"totalResults": len(teams),
+	})
+}
+
+func (h *Handlers) GetMyTeam(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, err := strconv.Atoi(c.Param("eventID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Event ID"}})
+		return
+	}
+
+	team, err := h.TeamService.GetTeamByEventAndUser(eventID, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Team Retrieved Successfully",
+		"data":    team,
+	})
+}
+
+func (h *Handlers) InviteMember(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, teamID, ok := parseEventAndTeamID(c)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		UsernameOrEmail string `json:"username_or_email"`
+	}
+	if err := c.BindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	invitation, err := h.TeamService.InviteMember(eventID, teamID, userID, request.UsernameOrEmail)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Invitation Sent Successfully",
+		"data":    invitation,
+	})
+}
+
+func (h *Handlers) RegisterTeam(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, teamID, ok := parseEventAndTeamID(c)
+	if !ok {
+		return
+	}
+
+	// The request body with additional notes is optional
+	var eventRegistration models.EventRegistration
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&eventRegistration); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{err.Error()}})
+			return
+		}
+	}
+
+	team, err := h.TeamService.RegisterTeam(eventID, teamID, userID, eventRegistration.AdditionalNotes)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Team Registered Successfully",
+		"data":    team,
+		"relationships": gin.H{
+			"event": gin.H{
+				"data": gin.H{
+					"id": eventID,
+				},
+			},
+		},
+	})
+}
+
+func (h *Handlers) RemoveMember(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, teamID, ok := parseEventAndTeamID(c)
+	if !ok {
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid User ID"}})
+		return
+	}
+
+	if err := h.TeamService.RemoveMember(eventID, teamID, userID, memberID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Member Removed Successfully",
+	})
+}
+
+func (h *Handlers) DisbandTeam(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	eventID, teamID, ok := parseEventAndTeamID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.TeamService.DisbandTeam(eventID, teamID, userID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Team Disbanded Successfully",
+	})
+}
+
+func (h *Handlers) ListMyInvitations(c *gin.Context) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	invitations, err := h.TeamService.ListInvitationsByUser(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Invitations Retrieved Successfully",
+		"data":    invitations,
+	})
+}
+
+func (h *Handlers) AcceptInvitation(c *gin.Context) {
+	h.respondToInvitation(c, true)
+}
+
+func (h *Handlers) DeclineInvitation(c *gin.Context) {
+	h.respondToInvitation(c, false)
+}
+
+func (h *Handlers) respondToInvitation(c *gin.Context, accept bool) {
+	userID, err := (&auth.Handlers{}).ExtractUserIDAndCheckPermission(c, "events:register")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": []string{err.Error()}})
+		return
+	}
+
+	invitationID, err := strconv.Atoi(c.Param("invitationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": []string{"Invalid Invitation ID"}})
+		return
+	}
+
+	invitation, err := h.TeamService.RespondToInvitation(invitationID, userID, accept)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	message := "Invitation Declined Successfully"
+	if accept {
+		message = "Invitation Accepted Successfully"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": message,
+		"data":    invitation,
+	})
+}
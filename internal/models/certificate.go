@@ -0,0 +1,58 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	CertificateAudienceRegistered = "registered"
+	CertificateAudienceCheckedIn  = "checked_in"
+
+	CertificateLandscape = "landscape"
+	CertificatePortrait  = "portrait"
+)
+
+// CertificateTextField is a line of text printed on a certificate. X and Y are percentages of the page
+// from its top left corner, the text may use the placeholders {{name}}, {{event}}, {{date}},
+// {{organization}}, {{code}} and {{verification_url}}.
+type CertificateTextField struct {
+	Text     string  `json:"text"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	FontSize float64 `json:"font_size"`
+	Bold     bool    `json:"bold"`
+	Align    string  `json:"align"`
+	Color    string  `json:"color"`
+}
+
+// CertificateTemplate describes the certificates of an event and who receives them
+type CertificateTemplate struct {
+	EventID       int                    `json:"event_id"`
+	BackgroundURL *string                `json:"background_url"`
+	Orientation   string                 `json:"orientation"`
+	Audience      string                 `json:"audience"`
+	Fields        []CertificateTextField `json:"fields"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// Certificate is a generated certificate of participation, it can be verified with its code
+type Certificate struct {
+	ID              int       `json:"id"`
+	Code            string    `json:"code"`
+	EventID         int       `json:"event_id"`
+	UserID          uuid.UUID `json:"user_id"`
+	RecipientName   string    `json:"recipient_name"`
+	FileURL         string    `json:"file_url"`
+	VerificationURL string    `json:"verification_url"`
+	IssuedAt        time.Time `json:"issued_at"`
+	EventTitle      string    `json:"event_title"`
+	EventStartDate  time.Time `json:"event_start_date"`
+	Organization    string    `json:"organization"`
+}
+
+// CertificateRecipient is a registrant who should receive a certificate
+type CertificateRecipient struct {
+	UserID uuid.UUID
+	Name   string
+}
@@ -6,28 +6,79 @@ import (
 )
 
 type Event struct {
-	ID              int       `json:"id"`
-	Title           string    `json:"title"`
-	Description     string    `json:"description"`
-	StartDate       time.Time `json:"start_date"`
-	EndDate         time.Time `json:"end_date"`
-	UserID          uuid.UUID `json:"user_id"`
-	Status          string    `json:"status"`
-	Slug            string    `json:"slug"`
-	Thumbnail       string    `json:"thumbnail"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updatedAt"`
-	OrganizationID  int       `json:"organization_id"`
-	MaxRegistration *int      `json:"max_registration"`
-	Organization    string    `json:"organization"`
-	Author          string    `json:"author"`
-	TotalRegistered int       `json:"total_registered"`
+	ID               int         `json:"id"`
+	Title            string      `json:"title"`
+	Description      string      `json:"description"`
+	StartDate        time.Time   `json:"start_date"`
+	EndDate          time.Time   `json:"end_date"`
+	AllDay           bool        `json:"all_day"`
+	UserID           uuid.UUID   `json:"user_id"`
+	Status           EventStatus `json:"status"`
+	Slug             string      `json:"slug"`
+	Thumbnail        string      `json:"thumbnail"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updatedAt"`
+	OrganizationID   int         `json:"organization_id"`
+	MaxRegistration  *int        `json:"max_registration"`
+	TeamRegistration bool        `json:"team_registration"`
+	MinTeamSize      *int        `json:"min_team_size"`
+	MaxTeamSize      *int        `json:"max_team_size"`
+	OpenForAll       bool        `json:"open_for_all"`
+	SeriesID         *int        `json:"series_id"`
+	Timezone         string      `json:"timezone"`
+	Venue            *string     `json:"venue"`
+	OnlineMeetingURL *string     `json:"online_meeting_url"`
+	Organization     string      `json:"organization"`
+	Author           string      `json:"author"`
+	TotalRegistered  int         `json:"total_registered"`
+	Hosts            []EventHost `json:"hosts,omitempty"`
 }
 
 type EventRegistration struct {
-	ID               int       `json:"id"`
-	EventID          int       `json:"event_id"`
-	UserID           uuid.UUID `json:"user_id"`
-	RegistrationDate time.Time `json:"registration_date"`
-	AdditionalNotes  string    `json:"additional_notes"`
+	ID               int               `json:"id"`
+	EventID          int               `json:"event_id"`
+	UserID           uuid.UUID         `json:"user_id"`
+	RegistrationDate time.Time         `json:"registration_date"`
+	AdditionalNotes  string            `json:"additional_notes"`
+	TeamID           *int              `json:"team_id"`
+	FormAnswers      map[string]string `json:"form_answers"`
+	CheckedInAt      *time.Time        `json:"checked_in_at"`
+}
+
+// RegistrationExportRow is a single registrant as exported for organizers
+type RegistrationExportRow struct {
+	FirstName   string
+	LastName    string
+	StudentID   *string
+	Major       *string
+	Year        string
+	Email       string
+	Notes       *string
+	TeamName    *string
+	FormAnswers map[string]string
+	CheckedInAt *time.Time
+}
+
+// EventReminderRecipient is a registrant who is due a reminder for an event
+type EventReminderRecipient struct {
+	UserID    uuid.UUID
+	Email     string
+	FirstName string
+	Event     *Event
+}
+
+// EventSeries is a recurring event, its occurrences are stored as regular events linked to the series
+type EventSeries struct {
+	ID             int         `json:"id"`
+	Title          string      `json:"title"`
+	RecurrenceRule string      `json:"recurrence_rule"`
+	Exceptions     []time.Time `json:"exceptions"`
+	StartDate      time.Time   `json:"start_date"`
+	EndDate        time.Time   `json:"end_date"`
+	Timezone       string      `json:"timezone"`
+	UserID         uuid.UUID   `json:"user_id"`
+	OrganizationID int         `json:"organization_id"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	Occurrences    []*Event    `json:"occurrences,omitempty"`
 }
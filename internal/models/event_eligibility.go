@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// EventEligibility holds the registration rules of an event, empty lists allow everyone
+type EventEligibility struct {
+	EventID                  int       `json:"event_id"`
+	AllowedMajors            []string  `json:"allowed_majors"`
+	AllowedYears             []string  `json:"allowed_years"`
+	AllowedRoleIDs           []int     `json:"allowed_role_ids"`
+	RequireStudentIDVerified bool      `json:"require_student_id_verified"`
+	OrganizationMembersOnly  bool      `json:"organization_members_only"`
+	UpdatedAt                time.Time `json:"updated_at"`
+}
+
+// EligibilityProfile is the part of a user that eligibility rules are evaluated against
+type EligibilityProfile struct {
+	Major             *string
+	Year              string
+	RoleID            int
+	RoleName          string
+	StudentIDVerified bool
+}
+
+type EligibilityReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type EligibilityResult struct {
+	Eligible bool                `json:"eligible"`
+	Reasons  []EligibilityReason `json:"reasons"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	FeedbackQuestionText   = "text"
+	FeedbackQuestionChoice = "choice"
+)
+
+// FeedbackQuestion is a question of a feedback survey, choice questions are answered with one of their options
+type FeedbackQuestion struct {
+	ID       string   `json:"id"`
+	Label    string   `json:"label"`
+	Type     string   `json:"type"`
+	Options  []string `json:"options,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// EventFeedbackSurvey is the survey registrants fill in after an event, every survey asks for a rating from 1 to 5
+type EventFeedbackSurvey struct {
+	EventID       int                `json:"event_id"`
+	Questions     []FeedbackQuestion `json:"questions"`
+	CheckedInOnly bool               `json:"checked_in_only"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// EventFeedback is the answer of one registrant to a feedback survey, answers are keyed by question id
+type EventFeedback struct {
+	ID          int               `json:"id"`
+	EventID     int               `json:"event_id"`
+	UserID      uuid.UUID         `json:"user_id"`
+	Rating      int               `json:"rating"`
+	Answers     map[string]string `json:"answers"`
+	SubmittedAt time.Time         `json:"submitted_at"`
+}
+
+// FeedbackQuestionResult aggregates the answers to one question,
+// text answers are listed while choice answers are counted per option
+type FeedbackQuestionResult struct {
+	Question FeedbackQuestion `json:"question"`
+	Answers  []string         `json:"answers,omitempty"`
+	Counts   map[string]int   `json:"counts,omitempty"`
+}
+
+// EventFeedbackResults aggregates the feedback of an event for its organizers
+type EventFeedbackResults struct {
+	EventID            int                      `json:"event_id"`
+	Responses          int                      `json:"responses"`
+	AverageRating      float64                  `json:"average_rating"`
+	RatingDistribution map[int]int              `json:"rating_distribution"`
+	Questions          []FeedbackQuestionResult `json:"questions"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// EventHost is an organization hosting an event, every event has exactly one primary host
+type EventHost struct {
+	OrganizationID int    `json:"organization_id"`
+	Organization   string `json:"organization"`
+	IsPrimary      bool   `json:"is_primary"`
+}
+
+// EventHostsUpdate replaces the hosts of an event
+type EventHostsUpdate struct {
+	PrimaryOrganizationID int   `json:"primary_organization_id"`
+	CoHostOrganizationIDs []int `json:"co_host_organization_ids"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Orders in which events can be listed
+const (
+	EventSortCreatedAt = "created_at"
+	EventSortStartDate = "start_date"
+	EventSortTitle     = "title"
+	EventSortRelevance = "relevance"
+)
+
+// EventListFilter narrows down and orders a list of events, unset fields do not filter
+type EventListFilter struct {
+	// Search is matched against the title and description, with the syntax of web search engines
+	Search         string
+	OrganizationID *int
+	// Statuses defaults to every status but draft
+	Statuses []EventStatus
+	// From and To match the events taking place on these calendar days, in the timezone of each event
+	From        *time.Time
+	To          *time.Time
+	OpenForAll  *bool
+	HasCapacity *bool
+
+	Sort       string
+	Descending bool
+	Limit      int
+	Offset     int
+
+	// AfterValue and AfterID continue the list after the event with this sort value and id
+	AfterValue *string
+	AfterID    int
+}
+
+// EventList is a page of events along with the number of events matching the filter
+type EventList struct {
+	Events     []*Event `json:"events"`
+	Total      int      `json:"total"`
+	TotalPages int      `json:"total_pages"`
+	NextCursor *string  `json:"next_cursor"`
+}
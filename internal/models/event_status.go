@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// EventStatus is the lifecycle state of an event:
+// draft -> published -> open -> ended, with cancelled and postponed reachable before an event ends.
+// Published, open and ended follow the event dates, the other states are only set by organizers.
+type EventStatus string
+
+const (
+	EventStatusDraft     EventStatus = "draft"
+	EventStatusPublished EventStatus = "published"
+	EventStatusOpen      EventStatus = "open"
+	EventStatusEnded     EventStatus = "ended"
+	EventStatusCancelled EventStatus = "cancelled"
+	EventStatusPostponed EventStatus = "postponed"
+)
+
+var eventStatusTransitions = map[EventStatus][]EventStatus{
+	EventStatusDraft:     {EventStatusPublished, EventStatusCancelled},
+	EventStatusPublished: {EventStatusDraft, EventStatusOpen, EventStatusEnded, EventStatusPostponed, EventStatusCancelled},
+	EventStatusOpen:      {EventStatusEnded, EventStatusPostponed, EventStatusCancelled},
+	EventStatusPostponed: {EventStatusPublished, EventStatusCancelled},
+	EventStatusEnded:     {},
+	EventStatusCancelled: {},
+}
+
+// IsValid tells whether s is a known status
+func (s EventStatus) IsValid() bool {
+	_, ok := eventStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo tells whether an event may move from s to next
+func (s EventStatus) CanTransitionTo(next EventStatus) bool {
+	for _, allowed := range eventStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDateDriven tells whether the status is kept in sync with the event dates
+func (s EventStatus) IsDateDriven() bool {
+	return s == EventStatusPublished || s == EventStatusOpen || s == EventStatusEnded
+}
+
+// AcceptsRegistrations tells whether users may register for an event in this status
+func (s EventStatus) AcceptsRegistrations() bool {
+	return s == EventStatusPublished || s == EventStatusOpen
+}
+
+// EventStatusForDates returns the date driven status of a published event at the given time
+func EventStatusForDates(start, end, now time.Time) EventStatus {
+	switch {
+	case now.Before(start):
+		return EventStatusPublished
+	case now.Before(end):
+		return EventStatusOpen
+	default:
+		return EventStatusEnded
+	}
+}
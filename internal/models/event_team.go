@@ -0,0 +1,49 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	TeamRoleCaptain = "captain"
+	TeamRoleMember  = "member"
+
+	InvitationPending   = "pending"
+	InvitationAccepted  = "accepted"
+	InvitationDeclined  = "declined"
+	InvitationCancelled = "cancelled"
+)
+
+type EventTeam struct {
+	ID           int                `json:"id"`
+	EventID      int                `json:"event_id"`
+	Name         string             `json:"name"`
+	CaptainID    uuid.UUID          `json:"captain_id"`
+	RegisteredAt *time.Time         `json:"registered_at"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	Members      []*EventTeamMember `json:"members"`
+}
+
+type EventTeamMember struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+type EventTeamInvitation struct {
+	ID          int        `json:"id"`
+	TeamID      int        `json:"team_id"`
+	TeamName    string     `json:"team_name"`
+	EventID     int        `json:"event_id"`
+	EventTitle  string     `json:"event_title"`
+	InviteeID   uuid.UUID  `json:"invitee_id"`
+	InvitedBy   uuid.UUID  `json:"invited_by"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// EventTemplate is an event saved under a name so organizers can create similar events from it
+type EventTemplate struct {
+	ID             int                   `json:"id"`
+	Name           string                `json:"name"`
+	OrganizationID int                   `json:"organization_id"`
+	Organization   string                `json:"organization"`
+	UserID         uuid.UUID             `json:"user_id"`
+	Thumbnail      *string               `json:"thumbnail"`
+	Settings       EventTemplateSettings `json:"settings"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// EventTemplateSettings are the parts of an event kept by a template. The schedule is kept as a time of day
+// and a duration, the date is chosen when an event is created from the template.
+type EventTemplateSettings struct {
+	Title                 string            `json:"title"`
+	Description           string            `json:"description"`
+	StartTime             string            `json:"start_time"`
+	DurationSeconds       int64             `json:"duration_seconds"`
+	AllDay                bool              `json:"all_day"`
+	Timezone              string            `json:"timezone"`
+	Venue                 *string           `json:"venue"`
+	OnlineMeetingURL      *string           `json:"online_meeting_url"`
+	MaxRegistration       *int              `json:"max_registration"`
+	TeamRegistration      bool              `json:"team_registration"`
+	MinTeamSize           *int              `json:"min_team_size"`
+	MaxTeamSize           *int              `json:"max_team_size"`
+	OpenForAll            bool              `json:"open_for_all"`
+	Eligibility           *EventEligibility `json:"eligibility"`
+	CoHostOrganizationIDs []int             `json:"co_host_organization_ids"`
+}
+
+// EventCopyRequest creates an event from an existing event or a template. The start date is either
+// a date, keeping the original time of day, or a full timestamp. Duplicates may be shifted by a number of days instead.
+type EventCopyRequest struct {
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	ShiftDays int    `json:"shift_days"`
+}
@@ -6,17 +6,32 @@ import (
 )
 
 type News struct {
-	ID             int       `json:"id"`
-	Title          string    `json:"title"`
-	Content        string    `json:"content"`
-	UserID         uuid.UUID `json:"user_id"`
-	PublishDate    time.Time `json:"publish_date"`
-	Likes          int       `json:"likes"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	Thumbnail      string    `json:"thumbnail"`
-	Slug           string    `json:"slug"`
-	OrganizationID int       `json:"organization_id"`
-	Organization   string    `json:"organization"`
-	Author         string    `json:"author"`
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	// Content is the source written by the editor in ContentFormat, ContentHTML its sanitized rendering
+	// and ContentText the plain text of the rendering, which search indexes
+	Content        string     `json:"content"`
+	ContentFormat  string     `json:"content_format"`
+	ContentHTML    string     `json:"content_html"`
+	ContentText    string     `json:"-"`
+	Excerpt        string     `json:"excerpt"`
+	ReadingTime    int        `json:"reading_time"`
+	UserID         uuid.UUID  `json:"user_id"`
+	PublishDate    time.Time  `json:"publish_date"`
+	Likes          int        `json:"likes"`
+	Views          int64      `json:"views"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Thumbnail      string     `json:"thumbnail"`
+	Slug           string     `json:"slug"`
+	OrganizationID int        `json:"organization_id"`
+	Organization   string     `json:"organization"`
+	Author         string     `json:"author"`
+	Status         NewsStatus `json:"status"`
+	LikedByMe      bool       `json:"liked_by_me"`
+	Tags           []NewsTag  `json:"tags"`
+	// TrendingScore is only set in the trending listing
+	TrendingScore float64 `json:"trending_score,omitempty"`
+	// TagIDs replaces the tags of a news when it is created or edited, it is left out of responses
+	TagIDs []int `json:"tag_ids,omitempty"`
 }
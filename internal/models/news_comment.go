@@ -0,0 +1,44 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// NewsComment is a comment on a news, top level comments carry their replies.
+// Deleted and hidden comments that still have replies are kept with an empty content.
+type NewsComment struct {
+	ID        int            `json:"id"`
+	NewsID    int            `json:"news_id"`
+	UserID    uuid.UUID      `json:"user_id"`
+	Author    string         `json:"author"`
+	ParentID  *int           `json:"parent_id"`
+	Content   string         `json:"content"`
+	Hidden    bool           `json:"hidden"`
+	Deleted   bool           `json:"deleted"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	Replies   []*NewsComment `json:"replies,omitempty"`
+}
+
+// NewsCommentInput is the body of a new or edited comment, ParentID is only read for new comments
+type NewsCommentInput struct {
+	Content  string `json:"content"`
+	ParentID *int   `json:"parent_id"`
+}
+
+const (
+	NewsCommentReportOpen      = "open"
+	NewsCommentReportResolved  = "resolved"
+	NewsCommentReportDismissed = "dismissed"
+)
+
+// ReportedNewsComment is an entry of the moderation queue, a comment with its open reports
+type ReportedNewsComment struct {
+	Comment        NewsComment `json:"comment"`
+	NewsTitle      string      `json:"news_title"`
+	NewsSlug       string      `json:"news_slug"`
+	Reports        int         `json:"reports"`
+	Reasons        []string    `json:"reasons"`
+	LastReportedAt time.Time   `json:"last_reported_at"`
+}
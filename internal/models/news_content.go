@@ -0,0 +1,6 @@
+package models
+
+const (
+	NewsContentMarkdown = "markdown"
+	NewsContentHTML     = "html"
+)
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"Backend/pkg/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+// NewsRevision is a saved version of the title, content and thumbnail of a news
+type NewsRevision struct {
+	ID            int        `json:"id"`
+	NewsID        int        `json:"news_id"`
+	Revision      int        `json:"revision"`
+	Title         string     `json:"title"`
+	Content       string     `json:"content,omitempty"`
+	ContentFormat string     `json:"content_format"`
+	Thumbnail     string     `json:"thumbnail"`
+	ThumbnailKey  *string    `json:"-"`
+	EditorID      *uuid.UUID `json:"editor_id"`
+	Editor        string     `json:"editor"`
+	RestoredFrom  *int       `json:"restored_from"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// NewsRevisionDiff compares two revisions of a news, Content lists the lines of the later one
+// along with the lines removed from the earlier one
+type NewsRevisionDiff struct {
+	From             *NewsRevision    `json:"from"`
+	To               *NewsRevision    `json:"to"`
+	TitleChanged     bool             `json:"title_changed"`
+	ThumbnailChanged bool             `json:"thumbnail_changed"`
+	FormatChanged    bool             `json:"format_changed"`
+	Content          []utils.DiffLine `json:"content"`
+	LinesAdded       int              `json:"lines_added"`
+	LinesRemoved     int              `json:"lines_removed"`
+}
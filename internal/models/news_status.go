@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// NewsStatus is the publication state of a news:
+// draft -> scheduled -> published -> archived, a published news may also be taken back to draft.
+// Only published news whose publish date has passed are shown publicly.
+type NewsStatus string
+
+const (
+	NewsStatusDraft     NewsStatus = "draft"
+	NewsStatusScheduled NewsStatus = "scheduled"
+	NewsStatusPublished NewsStatus = "published"
+	NewsStatusArchived  NewsStatus = "archived"
+)
+
+var newsStatusTransitions = map[NewsStatus][]NewsStatus{
+	NewsStatusDraft:     {NewsStatusScheduled, NewsStatusPublished, NewsStatusArchived},
+	NewsStatusScheduled: {NewsStatusDraft, NewsStatusPublished, NewsStatusArchived},
+	NewsStatusPublished: {NewsStatusDraft, NewsStatusArchived},
+	NewsStatusArchived:  {NewsStatusDraft, NewsStatusPublished},
+}
+
+// IsValid tells whether s is a known status
+func (s NewsStatus) IsValid() bool {
+	_, ok := newsStatusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo tells whether a news may move from s to next
+func (s NewsStatus) CanTransitionTo(next NewsStatus) bool {
+	for _, allowed := range newsStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDateDriven tells whether the status follows the publish date
+func (s NewsStatus) IsDateDriven() bool {
+	return s == NewsStatusScheduled || s == NewsStatusPublished
+}
+
+// NewsStatusForDate returns the date driven status of a news going live at publishDate
+func NewsStatusForDate(publishDate, now time.Time) NewsStatus {
+	if publishDate.After(now) {
+		return NewsStatusScheduled
+	}
+	return NewsStatusPublished
+}
+
+// NewsStatusUpdate is the body of a news status change, PublishDate schedules the news when it is in the future
+type NewsStatusUpdate struct {
+	Status      NewsStatus `json:"status"`
+	PublishDate *time.Time `json:"publish_date"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	NewsTagKindTag      = "tag"
+	NewsTagKindCategory = "category"
+)
+
+// NewsTag is a tag or a category news are filed under, Count is only filled in the tag cloud
+type NewsTag struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	Count       int       `json:"count,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	SearchTypeNews       = "news"
+	SearchTypeEvent      = "event"
+	SearchTypeAspiration = "aspiration"
+)
+
+// SearchResult is a news, an event or an aspiration matching a search. TitleHighlight and Snippet are
+// escaped HTML where the matching words are wrapped in <mark>.
+type SearchResult struct {
+	Type           string    `json:"type"`
+	ID             int       `json:"id"`
+	Slug           string    `json:"slug,omitempty"`
+	Title          string    `json:"title"`
+	TitleHighlight string    `json:"title_highlight"`
+	Snippet        string    `json:"snippet"`
+	Organization   string    `json:"organization"`
+	Rank           float64   `json:"rank"`
+	Date           time.Time `json:"date"`
+}
@@ -0,0 +1,344 @@
+package services
+
+import (
+	"Backend/configs"
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"github.com/google/uuid"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	certificateDirectory           = "certificates"
+	certificateBackgroundDirectory = "certificate-templates"
+	maxCertificateFields           = 20
+
+	// certificateCodeAlphabet leaves out characters that are easily confused such as 0 and O
+	certificateCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+type CertificateService struct {
+	Storage *S3Service
+}
+
+func NewCertificateService(storage *S3Service) *CertificateService {
+	return &CertificateService{Storage: storage}
+}
+
+// certificateVerificationURL is the public page where a certificate can be checked with its code
+func certificateVerificationURL(baseURL, code string) string {
+	return baseURL + "/certificates/" + code
+}
+
+// generateCertificateCode returns a random code such as 7KQ2M-XH4PA
+func generateCertificateCode() (string, error) {
+	random := make([]byte, 10)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	code := make([]byte, 0, 11)
+	for i, b := range random {
+		if i == 5 {
+			code = append(code, '-')
+		}
+		code = append(code, certificateCodeAlphabet[int(b)%len(certificateCodeAlphabet)])
+	}
+	return string(code), nil
+}
+
+// validateCertificateTemplate checks a template and fills in the defaults of its fields
+func validateCertificateTemplate(template *models.CertificateTemplate) error {
+	switch template.Orientation {
+	case "":
+		template.Orientation = models.CertificateLandscape
+	case models.CertificateLandscape, models.CertificatePortrait:
+	default:
+		return utils.BadRequestError{Message: "Orientation must be landscape or portrait"}
+	}
+
+	switch template.Audience {
+	case "":
+		template.Audience = models.CertificateAudienceCheckedIn
+	case models.CertificateAudienceCheckedIn, models.CertificateAudienceRegistered:
+	default:
+		return utils.BadRequestError{Message: "Audience must be checked_in or registered"}
+	}
+
+	if len(template.Fields) == 0 {
+		return utils.BadRequestError{Message: "A certificate needs at least one text field"}
+	}
+	if len(template.Fields) > maxCertificateFields {
+		return utils.BadRequestError{Message: fmt.Sprintf("A certificate can have at most %d text fields", maxCertificateFields)}
+	}
+
+	for i := range template.Fields {
+		field := &template.Fields[i]
+		field.Text = strings.TrimSpace(field.Text)
+
+		if field.Text == "" {
+			return utils.BadRequestError{Message: fmt.Sprintf("Text field %d is empty", i+1)}
+		}
+		if field.X < 0 || field.X > 100 || field.Y < 0 || field.Y > 100 {
+			return utils.BadRequestError{Message: fmt.Sprintf("Text field %d must be placed within the page, x and y are percentages", i+1)}
+		}
+
+		if field.FontSize == 0 {
+			field.FontSize = 16
+		}
+		if field.FontSize < 6 || field.FontSize > 96 {
+			return utils.BadRequestError{Message: fmt.Sprintf("Font size of text field %d must be between 6 and 96", i+1)}
+		}
+
+		switch field.Align {
+		case "":
+			field.Align = utils.PDFAlignCenter
+		case utils.PDFAlignLeft, utils.PDFAlignCenter, utils.PDFAlignRight:
+		default:
+			return utils.BadRequestError{Message: fmt.Sprintf("Alignment of text field %d must be left, center or right", i+1)}
+		}
+
+		if field.Color == "" {
+			field.Color = "#000000"
+		}
+		if _, err := parseHexColor(field.Color); err != nil {
+			return utils.BadRequestError{Message: fmt.Sprintf("Color of text field %d must be formatted as #RRGGBB", i+1)}
+		}
+	}
+
+	return nil
+}
+
+// parseHexColor converts a #RRGGBB color to the 0 to 1 components used by PDF
+func parseHexColor(value string) ([3]float64, error) {
+	var rgb [3]float64
+	if len(value) != 7 || value[0] != '#' {
+		return rgb, fmt.Errorf("invalid color %q", value)
+	}
+
+	for i := 0; i < 3; i++ {
+		component, err := strconv.ParseUint(value[1+i*2:3+i*2], 16, 8)
+		if err != nil {
+			return rgb, err
+		}
+		rgb[i] = float64(component) / 255
+	}
+	return rgb, nil
+}
+
+// formatCertificateDate formats the dates of an event in its timezone, such as "7 March 2025"
+func formatCertificateDate(event *models.Event) string {
+	location := eventLocation(event)
+	start, end := event.StartDate.In(location), event.EndDate.In(location)
+
+	if start.Format("2006-01-02") == end.Format("2006-01-02") {
+		return start.Format("2 January 2006")
+	}
+	return start.Format("2 January 2006") + " - " + end.Format("2 January 2006")
+}
+
+// renderCertificate fills in the template for one recipient and renders it as a PDF
+func renderCertificate(template *models.CertificateTemplate, background []byte, event *models.Event, certificate *models.Certificate) ([]byte, error) {
+	width, height := utils.PDFA4Height, utils.PDFA4Width
+	if template.Orientation == models.CertificatePortrait {
+		width, height = utils.PDFA4Width, utils.PDFA4Height
+	}
+
+	page := utils.NewPDFPage(width, height)
+	if background != nil {
+		if err := page.SetBackgroundJPEG(background); err != nil {
+			return nil, err
+		}
+	}
+
+	replacer := strings.NewReplacer(
+		"{{name}}", certificate.RecipientName,
+		"{{event}}", event.Title,
+		"{{date}}", formatCertificateDate(event),
+		"{{organization}}", event.Organization,
+		"{{code}}", certificate.Code,
+		"{{verification_url}}", certificate.VerificationURL,
+	)
+
+	for _, field := range template.Fields {
+		rgb, _ := parseHexColor(field.Color)
+		x := field.X / 100 * width
+		y := height - field.Y/100*height
+		page.DrawText(replacer.Replace(field.Text), x, y, field.FontSize, field.Bold, field.Align, rgb)
+	}
+
+	return page.Bytes(), nil
+}
+
+// GetTemplate retrieves the certificate template of an event
+func (cs *CertificateService) GetTemplate(eventID int) (*models.CertificateTemplate, error) {
+	return app.GetCertificateTemplate(eventID)
+}
+
+// UpdateTemplate creates or replaces the layout of the certificates of an event
+func (cs *CertificateService) UpdateTemplate(template *models.CertificateTemplate) error {
+	if _, err := app.GetEventByID(template.EventID); err != nil {
+		return &utils.NotFoundError{Message: "Event not found"}
+	}
+
+	if err := validateCertificateTemplate(template); err != nil {
+		return err
+	}
+
+	return app.UpsertCertificateTemplate(template)
+}
+
+// UploadBackground stores the JPEG background of the certificates of an event
+func (cs *CertificateService) UploadBackground(ctx context.Context, eventID int, image []byte) (string, error) {
+	if _, err := app.GetEventByID(eventID); err != nil {
+		return "", &utils.NotFoundError{Message: "Event not found"}
+	}
+
+	key := strconv.Itoa(eventID)
+	if err := cs.Storage.UploadFileToR2(ctx, certificateBackgroundDirectory, key, image); err != nil {
+		return "", err
+	}
+
+	backgroundURL, err := cs.Storage.GetFileR2(certificateBackgroundDirectory, key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := app.SetCertificateBackground(eventID, backgroundURL); err != nil {
+		return "", err
+	}
+
+	return backgroundURL, nil
+}
+
+// GenerateCertificates issues certificates to every registrant of an ended event who has none yet,
+// or only to those who checked in depending on the template. Certificates already issued are kept.
+func (cs *CertificateService) GenerateCertificates(ctx context.Context, eventID int) ([]*models.Certificate, error) {
+	event, err := app.GetEventByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Status == models.EventStatusCancelled || event.Status == models.EventStatusDraft {
+		return nil, utils.BadRequestError{Message: fmt.Sprintf("Certificates cannot be issued for an event that is %s", event.Status)}
+	}
+	if time.Now().Before(event.EndDate) {
+		return nil, utils.BadRequestError{Message: "Certificates can be issued once the event has ended"}
+	}
+
+	template, err := app.GetCertificateTemplate(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCertificateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	var background []byte
+	if template.BackgroundURL != nil {
+		background, err = cs.Storage.DownloadFileR2(ctx, certificateBackgroundDirectory, strconv.Itoa(eventID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recipients, err := app.ListCertificateRecipients(eventID, template.Audience == models.CertificateAudienceCheckedIn)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := configs.LoadConfig().BaseURL
+
+	certificates := []*models.Certificate{}
+	for _, recipient := range recipients {
+		certificate, err := cs.issueCertificate(ctx, template, background, event, recipient, baseURL)
+		if err != nil {
+			log.Printf("Error issuing certificate of event %d to %s: %v", eventID, recipient.UserID, err)
+			return certificates, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+func (cs *CertificateService) issueCertificate(ctx context.Context, template *models.CertificateTemplate, background []byte, event *models.Event, recipient *models.CertificateRecipient, baseURL string) (*models.Certificate, error) {
+	code, err := generateCertificateCode()
+	if err != nil {
+		return nil, err
+	}
+
+	certificate := &models.Certificate{
+		Code:            code,
+		EventID:         event.ID,
+		UserID:          recipient.UserID,
+		RecipientName:   strings.TrimSpace(recipient.Name),
+		VerificationURL: certificateVerificationURL(baseURL, code),
+		EventTitle:      event.Title,
+		EventStartDate:  event.StartDate,
+		Organization:    event.Organization,
+	}
+
+	pdf, err := renderCertificate(template, background, event, certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := code + ".pdf"
+	if err := cs.Storage.UploadDocumentToR2(ctx, certificateDirectory, fileName, "application/pdf", pdf); err != nil {
+		return nil, err
+	}
+	certificate.FileURL = cs.Storage.GetDocumentR2(certificateDirectory, fileName)
+
+	if err := app.CreateCertificate(certificate); err != nil {
+		return nil, err
+	}
+
+	return certificate, nil
+}
+
+// VerifyCertificate retrieves a certificate by its code, codes are not case sensitive
+func (cs *CertificateService) VerifyCertificate(code string) (*models.Certificate, error) {
+	certificate, err := app.GetCertificateByCode(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+
+	certificate.VerificationURL = certificateVerificationURL(configs.LoadConfig().BaseURL, certificate.Code)
+	return certificate, nil
+}
+
+// ListEventCertificates retrieves the certificates issued for an event
+func (cs *CertificateService) ListEventCertificates(eventID int) ([]*models.Certificate, error) {
+	certificates, err := app.ListCertificatesByEvent(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := configs.LoadConfig().BaseURL
+	for _, certificate := range certificates {
+		certificate.VerificationURL = certificateVerificationURL(baseURL, certificate.Code)
+	}
+	return certificates, nil
+}
+
+// ListUserCertificates retrieves the certificates issued to a user
+func (cs *CertificateService) ListUserCertificates(userID uuid.UUID) ([]*models.Certificate, error) {
+	certificates, err := app.ListCertificatesByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := configs.LoadConfig().BaseURL
+	for _, certificate := range certificates {
+		certificate.VerificationURL = certificateVerificationURL(baseURL, certificate.Code)
+	}
+	return certificates, nil
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateCertificateCode(t *testing.T) {
+	format := regexp.MustCompile(`^[` + certificateCodeAlphabet + `]{5}-[` + certificateCodeAlphabet + `]{5}$`)
+	seen := map[string]bool{}
+
+	for i := 0; i < 200; i++ {
+		code, err := generateCertificateCode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !format.MatchString(code) {
+			t.Fatalf("generateCertificateCode() = %q, want five characters, a dash and five characters", code)
+		}
+		if strings.ContainsAny(code, "01IO") {
+			t.Fatalf("generateCertificateCode() = %q contains a confusable character", code)
+		}
+		if seen[code] {
+			t.Fatalf("generateCertificateCode() returned %q twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestValidateCertificateTemplate(t *testing.T) {
+	field := func(modify func(*models.CertificateTextField)) []models.CertificateTextField {
+		f := models.CertificateTextField{Text: "{{name}}", X: 50, Y: 40}
+		modify(&f)
+		return []models.CertificateTextField{f}
+	}
+	unchanged := func(*models.CertificateTextField) {}
+
+	tests := []struct {
+		name     string
+		template models.CertificateTemplate
+		wantErr  bool
+	}{
+		{"defaults", models.CertificateTemplate{Fields: field(unchanged)}, false},
+		{"portrait for registrants", models.CertificateTemplate{Orientation: "portrait", Audience: "registered", Fields: field(unchanged)}, false},
+		{"unknown orientation", models.CertificateTemplate{Orientation: "square", Fields: field(unchanged)}, true},
+		{"unknown audience", models.CertificateTemplate{Audience: "everyone", Fields: field(unchanged)}, true},
+		{"no fields", models.CertificateTemplate{}, true},
+		{"too many fields", models.CertificateTemplate{Fields: make([]models.CertificateTextField, maxCertificateFields+1)}, true},
+		{"blank text", models.CertificateTemplate{Fields: field(func(f *models.CertificateTextField) { f.Text = "   " })}, true},
+		{"outside the page", models.CertificateTemplate{Fields: field(func(f *models.CertificateTextField) { f.X = 101 })}, true},
+		{"negative position", models.CertificateTemplate{Fields: field(func(f *models.CertificateTextField) { f.Y = -1 })}, true},
+		{"font too small", models.CertificateTemplate{Fields: field(func(f *models.CertificateTextField) { f.FontSize = 5 })}, true},
+		{"font too large", models.CertificateTemplate{Fields: field(func(f *models.CertificateTextField) { f.FontSize = 97 })}, true},
+		{"unknown alignment", models.CertificateTemplate{Fields: field(func(f *models.CertificateTextField) { f.Align = "justify" })}, true},
+		{"short color", models.CertificateTemplate{Fields: field(func(f *models.CertificateTextField) { f.Color = "#fff" })}, true},
+		{"invalid color", models.CertificateTemplate{Fields: field(func(f *models.CertificateTextField) { f.Color = "#GG0000" })}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCertificateTemplate(&tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateCertificateTemplate() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if _, ok := err.(utils.BadRequestError); !ok {
+					t.Errorf("validateCertificateTemplate() error = %T, want utils.BadRequestError", err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCertificateTemplateDefaults(t *testing.T) {
+	template := models.CertificateTemplate{Fields: []models.CertificateTextField{{Text: "  {{name}}  ", X: 50, Y: 40}}}
+	if err := validateCertificateTemplate(&template); err != nil {
+		t.Fatal(err)
+	}
+
+	field := template.Fields[0]
+	if template.Orientation != models.CertificateLandscape || template.Audience != models.CertificateAudienceCheckedIn {
+		t.Errorf("template defaults = %q, %q, want landscape for checked in attendees", template.Orientation, template.Audience)
+	}
+	if field.Text != "{{name}}" || field.FontSize != 16 || field.Align != utils.PDFAlignCenter || field.Color != "#000000" {
+		t.Errorf("field defaults = %+v", field)
+	}
+}
+
+func TestRenderCertificate(t *testing.T) {
+	event := &models.Event{
+		Title:        "Career (Fair)",
+		Organization: "HMIF",
+		Timezone:     "Asia/Jakarta",
+		StartDate:    time.Date(2025, 3, 6, 18, 0, 0, 0, time.UTC),
+		EndDate:      time.Date(2025, 3, 7, 3, 0, 0, 0, time.UTC),
+	}
+	certificate := &models.Certificate{RecipientName: "Siti Aminah", Code: "7KQ2M-XH4PA", VerificationURL: "https://example.com/certificates/7KQ2M-XH4PA"}
+
+	tests := []struct {
+		name        string
+		orientation string
+		text        string
+		wantBox     string
+		wantText    string
+	}{
+		{
+			name:        "landscape with placeholders",
+			orientation: models.CertificateLandscape,
+			text:        "{{name}} attended {{event}} by {{organization}}",
+			wantBox:     "/MediaBox [0 0 841.89 595.28]",
+			wantText:    `(Siti Aminah attended Career \(Fair\) by HMIF) Tj`,
+		},
+		{
+			name:        "portrait with the date in the event timezone",
+			orientation: models.CertificatePortrait,
+			text:        "{{date}}",
+			wantBox:     "/MediaBox [0 0 595.28 841.89]",
+			wantText:    "(7 March 2025) Tj",
+		},
+		{
+			name:        "verification",
+			orientation: models.CertificateLandscape,
+			text:        "{{code}} {{verification_url}}",
+			wantBox:     "/MediaBox [0 0 841.89 595.28]",
+			wantText:    "(7KQ2M-XH4PA https://example.com/certificates/7KQ2M-XH4PA) Tj",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &models.CertificateTemplate{
+				Orientation: tt.orientation,
+				Fields:      []models.CertificateTextField{{Text: tt.text, X: 50, Y: 50, Align: utils.PDFAlignLeft}},
+			}
+			if err := validateCertificateTemplate(template); err != nil {
+				t.Fatal(err)
+			}
+
+			pdf, err := renderCertificate(template, nil, event, certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")) {
+				t.Fatalf("renderCertificate() is not a PDF: %q", pdf[:20])
+			}
+			if !bytes.Contains(pdf, []byte(tt.wantBox)) {
+				t.Errorf("renderCertificate() does not contain %q", tt.wantBox)
+			}
+			if !bytes.Contains(pdf, []byte(tt.wantText)) {
+				t.Errorf("renderCertificate() does not contain %q:\n%s", tt.wantText, pdf)
+			}
+		})
+	}
+}
+
+func TestRenderCertificateRejectsInvalidBackground(t *testing.T) {
+	template := &models.CertificateTemplate{Fields: []models.CertificateTextField{{Text: "{{name}}", X: 50, Y: 50}}}
+	if err := validateCertificateTemplate(template); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := renderCertificate(template, []byte("not a jpeg"), &models.Event{}, &models.Certificate{}); err == nil {
+		t.Error("renderCertificate() accepted a background that is not a JPEG image")
+	}
+}
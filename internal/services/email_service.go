@@ -1,6 +1,7 @@
 package services
 
 import (
+	"Backend/internal/models"
 	"github.com/google/uuid"
 )
 
@@ -11,4 +12,10 @@ type EmailService interface {
 	
 	// SendVerificationEmail sends a verification email with a link
 	SendVerificationEmail(to, token string, userId uuid.UUID) error
+
+	// SendEventStatusEmail tells a registrant that an event was cancelled or postponed
+	SendEventStatusEmail(to, name string, event *models.Event, reason string) error
+
+	// SendEventReminderEmail reminds a registrant of an upcoming event
+	SendEventReminderEmail(to, name string, event *models.Event) error
 }
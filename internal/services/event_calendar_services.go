@@ -0,0 +1,173 @@
+package services
+
+import (
+	"Backend/configs"
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// calendarFeedHistory is how long ended events stay in subscribed feeds
+const calendarFeedHistory = 180 * 24 * time.Hour
+
+// calendarUIDDomain makes event UIDs globally unique as required by iCalendar
+const calendarUIDDomain = "compsci.president.ac.id"
+
+// isWholeDay tells whether an event runs from the start of its first day to the end of its last day in its timezone
+func isWholeDay(start, end time.Time) bool {
+	return start.Hour() == 0 && start.Minute() == 0 && start.Second() == 0 &&
+		end.Hour() == 23 && end.Minute() == 59 && end.Second() == 59
+}
+
+// eventCalendarLocation describes where an event takes place, the venue and the meeting link when both are set
+func eventCalendarLocation(event *models.Event) string {
+	var parts []string
+	if event.Venue != nil {
+		parts = append(parts, *event.Venue)
+	}
+	if event.OnlineMeetingURL != nil {
+		parts = append(parts, *event.OnlineMeetingURL)
+	}
+	return strings.Join(parts, " / ")
+}
+
+// toICalEvent converts an event for a calendar, events covering whole days become all-day events
+func toICalEvent(event *models.Event, baseURL string) utils.ICalEvent {
+	url := baseURL + "/event/" + event.Slug
+
+	status := "CONFIRMED"
+	switch event.Status {
+	case models.EventStatusCancelled:
+		status = "CANCELLED"
+	case models.EventStatusPostponed:
+		status = "TENTATIVE"
+	}
+
+	description := strings.TrimSpace(event.Description)
+	if description != "" {
+		description += "\n\n"
+	}
+	description += url
+
+	icalEvent := utils.ICalEvent{
+		UID:          fmt.Sprintf("event-%d@%s", event.ID, calendarUIDDomain),
+		Summary:      event.Title,
+		Description:  description,
+		Location:     eventCalendarLocation(event),
+		URL:          url,
+		Categories:   event.Organization,
+		Status:       status,
+		Start:        event.StartDate,
+		End:          event.EndDate,
+		TimeZone:     event.Timezone,
+		Created:      event.CreatedAt,
+		LastModified: event.UpdatedAt,
+	}
+
+	if event.AllDay {
+		location := eventLocation(event)
+		start, end := event.StartDate.In(location), event.EndDate.In(location)
+		// The end date of an all-day event is exclusive in iCalendar
+		icalEvent.AllDay = true
+		icalEvent.Start = start
+		icalEvent.End = end.AddDate(0, 0, 1)
+	}
+
+	return icalEvent
+}
+
+func buildEventCalendar(name string, events []*models.Event) []byte {
+	baseURL := configs.LoadConfig().BaseURL
+
+	icalEvents := make([]utils.ICalEvent, 0, len(events))
+	for _, event := range events {
+		icalEvents = append(icalEvents, toICalEvent(event, baseURL))
+	}
+
+	return utils.BuildICalendar(name, icalEvents)
+}
+
+// EventICalendar renders a single event as an .ics file
+func (es *EventService) EventICalendar(slug string) ([]byte, error) {
+	event, err := app.GetEventBySlug(slug)
+	if err != nil || event.Status == models.EventStatusDraft {
+		return nil, &utils.NotFoundError{Message: "Event not found"}
+	}
+
+	return buildEventCalendar(event.Title, []*models.Event{event}), nil
+}
+
+// PublicEventsICalendar renders the feed of all events
+func (es *EventService) PublicEventsICalendar() ([]byte, error) {
+	events, err := app.ListCalendarEvents(0, time.Now().Add(-calendarFeedHistory))
+	if err != nil {
+		return nil, err
+	}
+
+	return buildEventCalendar("PUFA Computer Science Events", events), nil
+}
+
+// OrganizationEventsICalendar renders the feed of the events of one organization
+func (es *EventService) OrganizationEventsICalendar(organizationID int) ([]byte, error) {
+	events, err := app.ListCalendarEvents(organizationID, time.Now().Add(-calendarFeedHistory))
+	if err != nil {
+		return nil, err
+	}
+
+	name := "Events"
+	if len(events) > 0 {
+		name = events[0].Organization + " Events"
+	}
+
+	return buildEventCalendar(name, events), nil
+}
+
+// UserEventsICalendar renders the feed of the events a user registered for, the user is identified by their feed token
+func (es *EventService) UserEventsICalendar(token string) ([]byte, error) {
+	userID, err := app.GetUserIDByCalendarToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := app.ListEventsRegisteredByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildEventCalendar("My Registered Events", events), nil
+}
+
+// GetCalendarFeedToken returns the calendar feed token of a user, creating one on first use
+func (es *EventService) GetCalendarFeedToken(userID uuid.UUID) (string, error) {
+	token, err := app.GetCalendarToken(userID)
+	if err != nil {
+		return "", err
+	}
+
+	if token != nil {
+		return *token, nil
+	}
+
+	return es.RotateCalendarFeedToken(userID)
+}
+
+// RotateCalendarFeedToken replaces the calendar feed token of a user, invalidating subscriptions using the old one
+func (es *EventService) RotateCalendarFeedToken(userID uuid.UUID) (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	token := hex.EncodeToString(bytes)
+	if err := app.SetCalendarToken(userID, token); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+)
+
+const (
+	EligibilityStudentsOnly         = "students_only"
+	EligibilityMajorNotAllowed      = "major_not_allowed"
+	EligibilityYearNotAllowed       = "year_not_allowed"
+	EligibilityRoleNotAllowed       = "role_not_allowed"
+	EligibilityStudentIDNotVerified = "student_id_not_verified"
+	EligibilityOrganizationOnly     = "organization_members_only"
+)
+
+// evaluateEligibility returns every rule of the event the user does not satisfy.
+// Organization membership is derived from the user's role, which is named after the organization.
+func evaluateEligibility(event *models.Event, rules *models.EventEligibility, profile *models.EligibilityProfile) []models.EligibilityReason {
+	reasons := []models.EligibilityReason{}
+
+	if !event.OpenForAll && strings.EqualFold(profile.RoleName, "guest") {
+		reasons = append(reasons, models.EligibilityReason{
+			Code:    EligibilityStudentsOnly,
+			Message: "This event is only open for students",
+		})
+	}
+
+	if len(rules.AllowedMajors) > 0 {
+		major := ""
+		if profile.Major != nil {
+			major = *profile.Major
+		}
+		if !containsFold(rules.AllowedMajors, major) {
+			reasons = append(reasons, models.EligibilityReason{
+				Code:    EligibilityMajorNotAllowed,
+				Message: "This event is only open for majors: " + strings.Join(rules.AllowedMajors, ", "),
+			})
+		}
+	}
+
+	if len(rules.AllowedYears) > 0 && !containsFold(rules.AllowedYears, profile.Year) {
+		reasons = append(reasons, models.EligibilityReason{
+			Code:    EligibilityYearNotAllowed,
+			Message: "This event is only open for batch years: " + strings.Join(rules.AllowedYears, ", "),
+		})
+	}
+
+	if len(rules.AllowedRoleIDs) > 0 {
+		allowed := false
+		for _, roleID := range rules.AllowedRoleIDs {
+			if roleID == profile.RoleID {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			reasons = append(reasons, models.EligibilityReason{
+				Code:    EligibilityRoleNotAllowed,
+				Message: fmt.Sprintf("Your role (%s) is not allowed to register for this event", profile.RoleName),
+			})
+		}
+	}
+
+	if rules.RequireStudentIDVerified && !profile.StudentIDVerified {
+		reasons = append(reasons, models.EligibilityReason{
+			Code:    EligibilityStudentIDNotVerified,
+			Message: "Your student ID must be verified to register for this event",
+		})
+	}
+
+	if rules.OrganizationMembersOnly {
+		// Members of any host organization may register for a co-hosted event
+		organizations := []string{event.Organization}
+		for _, host := range event.Hosts {
+			if !host.IsPrimary {
+				organizations = append(organizations, host.Organization)
+			}
+		}
+
+		if !containsFold(organizations, profile.RoleName) {
+			reasons = append(reasons, models.EligibilityReason{
+				Code:    EligibilityOrganizationOnly,
+				Message: "This event is only open for members of " + strings.Join(organizations, ", "),
+			})
+		}
+	}
+
+	return reasons
+}
+
+func containsFold(values []string, value string) bool {
+	value = strings.TrimSpace(value)
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkEligibility evaluates the eligibility rules of an event for a user
+func checkEligibility(eventID int, userID uuid.UUID) (*models.EligibilityResult, error) {
+	event, err := app.GetEventByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := app.GetEventEligibility(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rules.OrganizationMembersOnly {
+		if err := attachEventHosts(event); err != nil {
+			return nil, err
+		}
+	}
+
+	profile, err := app.GetEligibilityProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := evaluateEligibility(event, rules, profile)
+	return &models.EligibilityResult{Eligible: len(reasons) == 0, Reasons: reasons}, nil
+}
+
+// NotEligibleError is returned when a user does not satisfy the eligibility rules of an event, with the reasons why
+type NotEligibleError struct {
+	EventID int                        `json:"event_id"`
+	Reasons []models.EligibilityReason `json:"reasons"`
+}
+
+func (n NotEligibleError) Error() string {
+	messages := make([]string, len(n.Reasons))
+	for i, reason := range n.Reasons {
+		messages[i] = reason.Message
+	}
+	return "You are not eligible to register for this event: " + strings.Join(messages, "; ")
+}
+
+// requireEligibility returns a NotEligibleError when the user does not satisfy the event rules
+func requireEligibility(eventID int, userID uuid.UUID) error {
+	result, err := checkEligibility(eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !result.Eligible {
+		return NotEligibleError{EventID: eventID, Reasons: result.Reasons}
+	}
+	return nil
+}
+
+// GetEligibility retrieves the eligibility rules of an event
+func (es *EventService) GetEligibility(eventID int) (*models.EventEligibility, error) {
+	return app.GetEventEligibility(eventID)
+}
+
+// UpdateEligibility replaces the eligibility rules of an event
+func (es *EventService) UpdateEligibility(eligibility *models.EventEligibility) error {
+	eligibility.AllowedMajors = cleanValues(eligibility.AllowedMajors)
+	eligibility.AllowedYears = cleanValues(eligibility.AllowedYears)
+	if eligibility.AllowedRoleIDs == nil {
+		eligibility.AllowedRoleIDs = []int{}
+	}
+
+	return app.UpsertEventEligibility(eligibility)
+}
+
+// cleanValues trims values and drops empty ones
+func cleanValues(values []string) []string {
+	cleaned := []string{}
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			cleaned = append(cleaned, v)
+		}
+	}
+	return cleaned
+}
+
+// CheckEligibility tells whether a user may register for an event and why not
+func (es *EventService) CheckEligibility(eventID int, userID uuid.UUID) (*models.EligibilityResult, error) {
+	return checkEligibility(eventID, userID)
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"Backend/internal/models"
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateEligibility(t *testing.T) {
+	informatics := "Informatics"
+	event := &models.Event{
+		Organization: "HMIF",
+		Hosts: []models.EventHost{
+			{Organization: "HMIF", IsPrimary: true},
+			{Organization: "HMS", IsPrimary: false},
+		},
+	}
+	student := models.EligibilityProfile{Major: &informatics, Year: "2022", RoleID: 3, RoleName: "HMIF", StudentIDVerified: true}
+
+	tests := []struct {
+		name    string
+		event   *models.Event
+		rules   models.EventEligibility
+		profile models.EligibilityProfile
+		want    []string
+	}{
+		{
+			name:    "no rules",
+			event:   event,
+			profile: student,
+			want:    []string{},
+		},
+		{
+			name:    "guests need an event open for all",
+			event:   event,
+			profile: models.EligibilityProfile{RoleName: "Guest"},
+			want:    []string{EligibilityStudentsOnly},
+		},
+		{
+			name:    "guests may join an event open for all",
+			event:   &models.Event{OpenForAll: true},
+			profile: models.EligibilityProfile{RoleName: "guest"},
+			want:    []string{},
+		},
+		{
+			name:    "majors and years match regardless of case and spaces",
+			event:   event,
+			rules:   models.EventEligibility{AllowedMajors: []string{" informatics "}, AllowedYears: []string{"2022"}},
+			profile: student,
+			want:    []string{},
+		},
+		{
+			name:    "missing major",
+			event:   event,
+			rules:   models.EventEligibility{AllowedMajors: []string{"Informatics"}},
+			profile: models.EligibilityProfile{Year: "2022", RoleName: "HMIF"},
+			want:    []string{EligibilityMajorNotAllowed},
+		},
+		{
+			name:    "every failed rule is reported",
+			event:   event,
+			rules:   models.EventEligibility{AllowedYears: []string{"2023"}, AllowedRoleIDs: []int{1, 2}, RequireStudentIDVerified: true},
+			profile: models.EligibilityProfile{Year: "2022", RoleID: 3, RoleName: "HMIF"},
+			want:    []string{EligibilityYearNotAllowed, EligibilityRoleNotAllowed, EligibilityStudentIDNotVerified},
+		},
+		{
+			name:    "members of a co-host may join",
+			event:   event,
+			rules:   models.EventEligibility{OrganizationMembersOnly: true},
+			profile: models.EligibilityProfile{RoleName: "hms"},
+			want:    []string{},
+		},
+		{
+			name:    "other organizations may not join",
+			event:   event,
+			rules:   models.EventEligibility{OrganizationMembersOnly: true},
+			profile: models.EligibilityProfile{RoleName: "HMM"},
+			want:    []string{EligibilityOrganizationOnly},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := evaluateEligibility(tt.event, &tt.rules, &tt.profile)
+			codes := []string{}
+			for _, reason := range reasons {
+				codes = append(codes, reason.Code)
+			}
+			if !reflect.DeepEqual(codes, tt.want) {
+				t.Errorf("evaluateEligibility() = %v, want %v", codes, tt.want)
+			}
+		})
+	}
+}
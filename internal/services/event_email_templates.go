@@ -0,0 +1,115 @@
+package services
+
+import (
+	"Backend/configs"
+	"Backend/internal/models"
+	"fmt"
+	"html"
+)
+
+// generateEventStatusEmail creates the subject and HTML content telling a registrant that an event was cancelled or postponed
+func generateEventStatusEmail(name string, event *models.Event, reason string) (string, string) {
+	eventLink := configs.LoadConfig().BaseURL + "/event/" + event.Slug
+
+	subject := fmt.Sprintf("Event Postponed: %s", event.Title)
+	headline := "This event has been postponed"
+	message := "The organizers have postponed this event. We will let you know once a new date is set, your registration stays valid."
+	if event.Status == models.EventStatusCancelled {
+		subject = fmt.Sprintf("Event Cancelled: %s", event.Title)
+		headline = "This event has been cancelled"
+		message = "The organizers have cancelled this event. We are sorry for the inconvenience."
+	}
+
+	reasonHTML := ""
+	if reason != "" {
+		reasonHTML = fmt.Sprintf(`<p style="font-size: 16px; color: #666;"><strong>Reason:</strong> %s</p>`, html.EscapeString(reason))
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="text-align: center; margin-bottom: 20px;">
+        <img src="https://sg.pufacomputing.live/Logo%%20Puma.png" alt="PUFA Computing Logo" width="150" style="max-width: 100%%;">
+    </div>
+    <div style="background-color: #f9f9f9; border-radius: 5px; padding: 20px; border-top: 3px solid #003CE5;">
+        <h1 style="color: #000; text-align: center; margin-bottom: 20px;">%s</h1>
+        <p style="font-size: 16px; color: #666;">Hi %s,</p>
+        <p style="font-size: 16px; color: #666;">%s</p>
+        <p style="font-size: 18px; color: #000;"><strong>%s</strong><br>%s</p>
+        %s
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" style="background-color: #003CE5; color: white; padding: 12px 24px; text-decoration: none; border-radius: 5px; font-weight: bold;">View Event</a>
+        </div>
+    </div>
+    <div style="text-align: center; margin-top: 20px; font-size: 12px; color: #999;">
+        <p> 2025 PUFA Computing. All rights reserved.</p>
+        <p><a href="https://compsci.president.ac.id" style="color: #003CE5; text-decoration: none;">compsci.president.ac.id</a></p>
+    </div>
+</body>
+</html>
+`, html.EscapeString(subject), headline, html.EscapeString(name), message,
+		html.EscapeString(event.Title), html.EscapeString(formatEventSchedule(event)), reasonHTML, eventLink)
+
+	return subject, body
+}
+
+// generateEventReminderEmail creates the subject and HTML content reminding a registrant of an upcoming event
+func generateEventReminderEmail(name string, event *models.Event) (string, string) {
+	eventLink := configs.LoadConfig().BaseURL + "/event/" + event.Slug
+
+	subject := fmt.Sprintf("Reminder: %s", event.Title)
+
+	thumbnailHTML := ""
+	if event.Thumbnail != "" {
+		thumbnailHTML = fmt.Sprintf(`<img src="%s" alt="%s" style="width: 100%%; border-radius: 5px; margin-bottom: 20px;">`,
+			html.EscapeString(event.Thumbnail), html.EscapeString(event.Title))
+	}
+
+	locationHTML := ""
+	if event.Venue != nil {
+		locationHTML += fmt.Sprintf(`<p style="font-size: 16px; color: #666;"><strong>Venue:</strong> %s</p>`, html.EscapeString(*event.Venue))
+	}
+	if event.OnlineMeetingURL != nil {
+		locationHTML += fmt.Sprintf(`<p style="font-size: 16px; color: #666;"><strong>Online:</strong> <a href="%s" style="color: #003CE5;">%s</a></p>`,
+			html.EscapeString(*event.OnlineMeetingURL), html.EscapeString(*event.OnlineMeetingURL))
+	}
+
+	body := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+</head>
+<body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
+    <div style="text-align: center; margin-bottom: 20px;">
+        <img src="https://sg.pufacomputing.live/Logo%%20Puma.png" alt="PUFA Computing Logo" width="150" style="max-width: 100%%;">
+    </div>
+    <div style="background-color: #f9f9f9; border-radius: 5px; padding: 20px; border-top: 3px solid #003CE5;">
+        <h1 style="color: #000; text-align: center; margin-bottom: 20px;">See you soon!</h1>
+        %s
+        <p style="font-size: 16px; color: #666;">Hi %s,</p>
+        <p style="font-size: 16px; color: #666;">This is a reminder that an event you registered for is coming up.</p>
+        <p style="font-size: 18px; color: #000;"><strong>%s</strong><br>%s</p>
+        %s
+        <div style="text-align: center; margin: 30px 0;">
+            <a href="%s" style="background-color: #003CE5; color: white; padding: 12px 24px; text-decoration: none; border-radius: 5px; font-weight: bold;">View Event</a>
+        </div>
+        <p style="font-size: 14px; color: #999;">You can turn off event reminders in your account settings.</p>
+    </div>
+    <div style="text-align: center; margin-top: 20px; font-size: 12px; color: #999;">
+        <p> 2025 PUFA Computing. All rights reserved.</p>
+        <p><a href="https://compsci.president.ac.id" style="color: #003CE5; text-decoration: none;">compsci.president.ac.id</a></p>
+    </div>
+</body>
+</html>
+`, html.EscapeString(subject), thumbnailHTML, html.EscapeString(name),
+		html.EscapeString(event.Title), html.EscapeString(formatEventSchedule(event)), locationHTML, eventLink)
+
+	return subject, body
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"strings"
+	"time"
+)
+
+// RegistrationExportWriter receives exported rows, the first row written is the header
+type RegistrationExportWriter interface {
+	WriteRow(values []string) error
+}
+
+// registrationExportColumn is a column organizers can select when exporting registrants
+type registrationExportColumn struct {
+	header string
+	value  func(row *models.RegistrationExportRow) string
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+var registrationExportColumns = map[string]registrationExportColumn{
+	"name": {"Name", func(row *models.RegistrationExportRow) string {
+		return strings.TrimSpace(row.FirstName + " " + row.LastName)
+	}},
+	"student_id": {"Student ID", func(row *models.RegistrationExportRow) string { return optionalString(row.StudentID) }},
+	"major":      {"Major", func(row *models.RegistrationExportRow) string { return optionalString(row.Major) }},
+	"year":       {"Year", func(row *models.RegistrationExportRow) string { return row.Year }},
+	"email":      {"Email", func(row *models.RegistrationExportRow) string { return row.Email }},
+	"notes":      {"Notes", func(row *models.RegistrationExportRow) string { return optionalString(row.Notes) }},
+	"team":       {"Team", func(row *models.RegistrationExportRow) string { return optionalString(row.TeamName) }},
+	"check_in": {"Checked In", func(row *models.RegistrationExportRow) string {
+		if row.CheckedInAt != nil {
+			return "Yes"
+		}
+		return "No"
+	}},
+	"checked_in_at": {"Checked In At", func(row *models.RegistrationExportRow) string {
+		if row.CheckedInAt == nil {
+			return ""
+		}
+		return row.CheckedInAt.Format(time.RFC3339)
+	}},
+}
+
+// DefaultRegistrationExportColumns is used when no columns are selected, "answers" expands to one column per form question
+var DefaultRegistrationExportColumns = []string{"name", "student_id", "major", "year", "email", "notes", "team", "answers", "check_in", "checked_in_at"}
+
+// ExportRegistrations writes the registrants of an event with the selected columns, rows are streamed from the database one at a time
+func (es *EventService) ExportRegistrations(ctx context.Context, eventID int, columns []string, w RegistrationExportWriter) error {
+	if len(columns) == 0 {
+		columns = DefaultRegistrationExportColumns
+	}
+
+	for _, column := range columns {
+		if _, ok := registrationExportColumns[column]; !ok && column != "answers" {
+			return utils.BadRequestError{Message: "Unknown export column: " + column}
+		}
+	}
+
+	var questions []string
+	for _, column := range columns {
+		if column == "answers" {
+			var err error
+			if questions, err = app.ListFormAnswerKeys(eventID); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	header := make([]string, 0, len(columns)+len(questions))
+	for _, column := range columns {
+		if column == "answers" {
+			header = append(header, questions...)
+			continue
+		}
+		header = append(header, registrationExportColumns[column].header)
+	}
+	if err := w.WriteRow(header); err != nil {
+		return err
+	}
+
+	values := make([]string, 0, len(header))
+	return app.StreamRegistrationsForExport(ctx, eventID, func(row *models.RegistrationExportRow) error {
+		values = values[:0]
+		for _, column := range columns {
+			if column == "answers" {
+				for _, question := range questions {
+					values = append(values, row.FormAnswers[question])
+				}
+				continue
+			}
+			values = append(values, registrationExportColumns[column].value(row))
+		}
+		return w.WriteRow(values)
+	})
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	maxFeedbackQuestions    = 20
+	maxFeedbackAnswerLength = 2000
+)
+
+// validateFeedbackSurvey checks the questions of a survey, questions without an id are numbered
+func validateFeedbackSurvey(survey *models.EventFeedbackSurvey) error {
+	if len(survey.Questions) > maxFeedbackQuestions {
+		return utils.BadRequestError{Message: fmt.Sprintf("A survey can have at most %d questions", maxFeedbackQuestions)}
+	}
+
+	if survey.Questions == nil {
+		survey.Questions = []models.FeedbackQuestion{}
+	}
+
+	seen := make(map[string]bool, len(survey.Questions))
+	for i := range survey.Questions {
+		question := &survey.Questions[i]
+		question.ID = strings.TrimSpace(question.ID)
+		question.Label = strings.TrimSpace(question.Label)
+
+		if question.ID == "" {
+			question.ID = fmt.Sprintf("q%d", i+1)
+		}
+		if seen[question.ID] {
+			return utils.BadRequestError{Message: fmt.Sprintf("Question id %s is used more than once", question.ID)}
+		}
+		seen[question.ID] = true
+
+		if question.Label == "" {
+			return utils.BadRequestError{Message: fmt.Sprintf("Question %s has no label", question.ID)}
+		}
+
+		switch question.Type {
+		case models.FeedbackQuestionText:
+			question.Options = nil
+		case models.FeedbackQuestionChoice:
+			question.Options = cleanValues(question.Options)
+			if len(question.Options) < 2 {
+				return utils.BadRequestError{Message: fmt.Sprintf("Question %s needs at least two options", question.ID)}
+			}
+		default:
+			return utils.BadRequestError{Message: fmt.Sprintf("Question %s must be of type text or choice", question.ID)}
+		}
+	}
+
+	return nil
+}
+
+// validateFeedbackAnswers checks the answers of a feedback against the survey questions
+func validateFeedbackAnswers(survey *models.EventFeedbackSurvey, feedback *models.EventFeedback) error {
+	if feedback.Rating < 1 || feedback.Rating > 5 {
+		return utils.BadRequestError{Message: "Rating must be between 1 and 5"}
+	}
+
+	answers := make(map[string]string, len(survey.Questions))
+	for _, question := range survey.Questions {
+		answer := strings.TrimSpace(feedback.Answers[question.ID])
+
+		if answer == "" {
+			if question.Required {
+				return utils.BadRequestError{Message: fmt.Sprintf("Question \"%s\" is required", question.Label)}
+			}
+			continue
+		}
+
+		if question.Type == models.FeedbackQuestionChoice {
+			option, ok := matchOption(question.Options, answer)
+			if !ok {
+				return utils.BadRequestError{Message: fmt.Sprintf("\"%s\" is not an option of question \"%s\"", answer, question.Label)}
+			}
+			answer = option
+		}
+
+		if len(answer) > maxFeedbackAnswerLength {
+			return utils.BadRequestError{Message: fmt.Sprintf("Answers cannot be longer than %d characters", maxFeedbackAnswerLength)}
+		}
+
+		answers[question.ID] = answer
+	}
+
+	// Answers to unknown questions are dropped
+	feedback.Answers = answers
+	return nil
+}
+
+// matchOption finds the option an answer refers to, ignoring case
+func matchOption(options []string, answer string) (string, bool) {
+	for _, option := range options {
+		if strings.EqualFold(option, answer) {
+			return option, true
+		}
+	}
+	return "", false
+}
+
+// GetFeedbackSurvey retrieves the feedback survey of an event
+func (es *EventService) GetFeedbackSurvey(eventID int) (*models.EventFeedbackSurvey, error) {
+	return app.GetFeedbackSurvey(eventID)
+}
+
+// UpdateFeedbackSurvey creates or replaces the feedback survey of an event
+func (es *EventService) UpdateFeedbackSurvey(survey *models.EventFeedbackSurvey) error {
+	if err := validateFeedbackSurvey(survey); err != nil {
+		return err
+	}
+
+	return app.UpsertFeedbackSurvey(survey)
+}
+
+// SubmitFeedback stores the feedback of a registrant. Feedback opens once the event has ended
+// and, when the survey asks for it, only registrants who checked in may answer.
+func (es *EventService) SubmitFeedback(feedback *models.EventFeedback) error {
+	event, err := app.GetEventByID(feedback.EventID)
+	if err != nil {
+		return err
+	}
+
+	survey, err := app.GetFeedbackSurvey(feedback.EventID)
+	if err != nil {
+		return err
+	}
+
+	if event.Status == models.EventStatusCancelled {
+		return utils.BadRequestError{Message: "Feedback cannot be given for a cancelled event"}
+	}
+
+	if time.Now().Before(event.EndDate) {
+		return utils.BadRequestError{Message: "Feedback opens once the event has ended"}
+	}
+
+	registered, checkedInAt, err := app.GetRegistrationCheckIn(feedback.EventID, feedback.UserID)
+	if err != nil {
+		return err
+	}
+	if !registered {
+		return utils.UnauthorizedError{Message: "Only registrants can give feedback for this event"}
+	}
+	if survey.CheckedInOnly && checkedInAt == nil {
+		return utils.UnauthorizedError{Message: "Only registrants who checked in can give feedback for this event"}
+	}
+
+	if err := validateFeedbackAnswers(survey, feedback); err != nil {
+		return err
+	}
+
+	return app.CreateEventFeedback(feedback)
+}
+
+// GetFeedbackResults aggregates the feedback of an event: the rating average and distribution,
+// the option counts of choice questions and the answers to text questions
+func (es *EventService) GetFeedbackResults(eventID int) (*models.EventFeedbackResults, error) {
+	survey, err := app.GetFeedbackSurvey(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback, err := app.ListEventFeedback(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &models.EventFeedbackResults{
+		EventID:            eventID,
+		Responses:          len(feedback),
+		RatingDistribution: map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0},
+		Questions:          make([]models.FeedbackQuestionResult, 0, len(survey.Questions)),
+	}
+
+	for _, question := range survey.Questions {
+		result := models.FeedbackQuestionResult{Question: question}
+		if question.Type == models.FeedbackQuestionChoice {
+			result.Counts = make(map[string]int, len(question.Options))
+			for _, option := range question.Options {
+				result.Counts[option] = 0
+			}
+		} else {
+			result.Answers = []string{}
+		}
+		results.Questions = append(results.Questions, result)
+	}
+
+	total := 0
+	for _, f := range feedback {
+		total += f.Rating
+		results.RatingDistribution[f.Rating]++
+
+		for i := range results.Questions {
+			result := &results.Questions[i]
+			answer, ok := f.Answers[result.Question.ID]
+			if !ok {
+				continue
+			}
+
+			if result.Counts != nil {
+				result.Counts[answer]++
+			} else {
+				result.Answers = append(result.Answers, answer)
+			}
+		}
+	}
+
+	if len(feedback) > 0 {
+		results.AverageRating = math.Round(float64(total)/float64(len(feedback))*100) / 100
+	}
+
+	return results, nil
+}
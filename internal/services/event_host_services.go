@@ -0,0 +1,76 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"github.com/google/uuid"
+)
+
+// attachEventHosts fills in the hosts of events with a single query
+func attachEventHosts(events ...*models.Event) error {
+	eventIDs := make([]int, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+	}
+
+	hosts, err := app.ListEventHosts(eventIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		event.Hosts = hosts[event.ID]
+	}
+	return nil
+}
+
+// GetEventHosts retrieves the organizations hosting an event, the primary host first
+func (es *EventService) GetEventHosts(eventID int) ([]models.EventHost, error) {
+	if _, err := app.GetEventByID(eventID); err != nil {
+		return nil, &utils.NotFoundError{Message: "Event not found"}
+	}
+
+	hosts, err := app.ListEventHosts([]int{eventID})
+	if err != nil {
+		return nil, err
+	}
+
+	if hosts[eventID] == nil {
+		return []models.EventHost{}, nil
+	}
+	return hosts[eventID], nil
+}
+
+// UpdateEventHosts replaces the primary host and the co-hosts of an event
+func (es *EventService) UpdateEventHosts(eventID int, update *models.EventHostsUpdate) error {
+	if update.PrimaryOrganizationID <= 0 {
+		return utils.BadRequestError{Message: "Primary Organization ID is required"}
+	}
+
+	// The primary host and repeated organizations are not added again as co-hosts
+	seen := map[int]bool{update.PrimaryOrganizationID: true}
+	coHosts := []int{}
+	for _, organizationID := range update.CoHostOrganizationIDs {
+		if !seen[organizationID] {
+			seen[organizationID] = true
+			coHosts = append(coHosts, organizationID)
+		}
+	}
+
+	return app.SetEventHosts(eventID, update.PrimaryOrganizationID, coHosts)
+}
+
+// RequireEventManager returns an UnauthorizedError unless the user may manage the event,
+// which admins, the author of the event and the officers of its host organizations may
+func (es *EventService) RequireEventManager(eventID int, userID uuid.UUID) error {
+	allowed, err := app.CanManageEvent(eventID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return utils.UnauthorizedError{Message: "Only officers of the organizations hosting this event can manage it"}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultEventListLimit = 10
+	maxEventListLimit     = 100
+	maxEventSearchLength  = 200
+)
+
+// eventListCursor marks where the next page of a list of events starts. Sorted columns continue after
+// the last event of the page, results sorted by relevance continue at an offset.
+type eventListCursor struct {
+	Sort   string `json:"sort"`
+	Value  string `json:"value,omitempty"`
+	ID     int    `json:"id,omitempty"`
+	Offset int    `json:"offset,omitempty"`
+}
+
+func encodeEventListCursor(cursor eventListCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeEventListCursor(value string) (*eventListCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor eventListCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// parseOptionalBool parses a query parameter such as open_for_all, empty values do not filter
+func parseOptionalBool(name, value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, utils.BadRequestError{Message: fmt.Sprintf("%s must be true or false", name)}
+	}
+	return &parsed, nil
+}
+
+// parseOptionalDate parses a query parameter formatted as YYYY-MM-DD
+func parseOptionalDate(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, utils.BadRequestError{Message: fmt.Sprintf("%s must be a date formatted as YYYY-MM-DD", name)}
+	}
+	return &parsed, nil
+}
+
+// parseEventListFilter turns the query parameters of the list of events into a filter
+func parseEventListFilter(queryParams map[string]string) (*models.EventListFilter, error) {
+	filter := &models.EventListFilter{
+		Search: strings.TrimSpace(queryParams["search"]),
+		Limit:  defaultEventListLimit,
+	}
+
+	if len(filter.Search) > maxEventSearchLength {
+		return nil, utils.BadRequestError{Message: fmt.Sprintf("Search cannot be longer than %d characters", maxEventSearchLength)}
+	}
+
+	if value := queryParams["organization_id"]; value != "" {
+		organizationID, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, utils.BadRequestError{Message: "Invalid Organization ID"}
+		}
+		filter.OrganizationID = &organizationID
+	}
+
+	for _, value := range cleanValues(strings.Split(queryParams["status"], ",")) {
+		status := models.EventStatus(strings.ToLower(value))
+		if !status.IsValid() {
+			return nil, utils.BadRequestError{Message: fmt.Sprintf("Invalid status %s", value)}
+		}
+		// The list is public, drafts are only reachable by those who manage them
+		if status == models.EventStatusDraft {
+			return nil, utils.BadRequestError{Message: "Draft events cannot be listed"}
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	var err error
+	if filter.From, err = parseOptionalDate("from", queryParams["from"]); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseOptionalDate("to", queryParams["to"]); err != nil {
+		return nil, err
+	}
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return nil, utils.BadRequestError{Message: "to must not be before from"}
+	}
+
+	if filter.OpenForAll, err = parseOptionalBool("open_for_all", queryParams["open_for_all"]); err != nil {
+		return nil, err
+	}
+	if filter.HasCapacity, err = parseOptionalBool("has_capacity", queryParams["has_capacity"]); err != nil {
+		return nil, err
+	}
+
+	// Sorting defaults to relevance when searching and to the newest events otherwise
+	sort := queryParams["sort"]
+	if sort == "" {
+		sort = "-" + models.EventSortCreatedAt
+		if filter.Search != "" {
+			sort = models.EventSortRelevance
+		}
+	}
+	filter.Sort = strings.TrimPrefix(sort, "-")
+	filter.Descending = strings.HasPrefix(sort, "-")
+
+	switch filter.Sort {
+	case models.EventSortCreatedAt, models.EventSortStartDate, models.EventSortTitle:
+	case models.EventSortRelevance:
+		if filter.Search == "" {
+			return nil, utils.BadRequestError{Message: "Sorting by relevance requires a search"}
+		}
+		filter.Descending = true
+	default:
+		return nil, utils.BadRequestError{Message: "sort must be one of created_at, start_date, title or relevance, prefixed with - for descending order"}
+	}
+
+	if value := queryParams["limit"]; value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxEventListLimit {
+			return nil, utils.BadRequestError{Message: fmt.Sprintf("limit must be between 1 and %d", maxEventListLimit)}
+		}
+		filter.Limit = limit
+	}
+
+	if value := queryParams["cursor"]; value != "" {
+		if queryParams["page"] != "" {
+			return nil, utils.BadRequestError{Message: "Use either page or cursor"}
+		}
+
+		cursor, err := decodeEventListCursor(value)
+		if err != nil {
+			return nil, utils.BadRequestError{Message: "Invalid cursor"}
+		}
+		if cursor.Sort != eventListSortKey(filter) {
+			return nil, utils.BadRequestError{Message: "The cursor belongs to a list with another sort order"}
+		}
+
+		if filter.Sort == models.EventSortRelevance {
+			if cursor.Offset < 0 {
+				return nil, utils.BadRequestError{Message: "Invalid cursor"}
+			}
+			filter.Offset = cursor.Offset
+		} else {
+			filter.AfterValue = &cursor.Value
+			filter.AfterID = cursor.ID
+		}
+	} else if value := queryParams["page"]; value != "" {
+		// Pages are kept for older clients, cursors stay consistent when events are added in between
+		page, err := strconv.Atoi(value)
+		if err != nil || page < 1 {
+			return nil, utils.BadRequestError{Message: "page must be a positive number"}
+		}
+		filter.Offset = (page - 1) * filter.Limit
+	}
+
+	return filter, nil
+}
+
+// eventListSortKey is the sort order of a filter as written in the sort parameter, such as -start_date
+func eventListSortKey(filter *models.EventListFilter) string {
+	if filter.Descending && filter.Sort != models.EventSortRelevance {
+		return "-" + filter.Sort
+	}
+	return filter.Sort
+}
+
+// eventListCursorAfter returns the cursor of the page following an event
+func eventListCursorAfter(filter *models.EventListFilter, last *models.Event) string {
+	sort := eventListSortKey(filter)
+	cursor := eventListCursor{Sort: sort, ID: last.ID}
+
+	switch filter.Sort {
+	case models.EventSortRelevance:
+		cursor = eventListCursor{Sort: sort, Offset: filter.Offset + filter.Limit}
+	case models.EventSortStartDate:
+		cursor.Value = last.StartDate.Format(time.RFC3339Nano)
+	case models.EventSortTitle:
+		cursor.Value = last.Title
+	default:
+		cursor.Value = last.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	return encodeEventListCursor(cursor)
+}
+
+// ListEvents retrieves a page of the events matching the query parameters: search, organization_id, status,
+// from, to, open_for_all, has_capacity, sort, limit and either cursor or page
+func (es *EventService) ListEvents(queryParams map[string]string) (*models.EventList, error) {
+	filter, err := parseEventListFilter(queryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	// One more event than asked for tells whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+	events, total, err := app.ListEvents(filter)
+	filter.Limit = limit
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachEventHosts(events...); err != nil {
+		return nil, err
+	}
+
+	list := &models.EventList{
+		Events:     events,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}
+
+	if len(events) > limit {
+		list.Events = events[:limit]
+		nextCursor := eventListCursorAfter(filter, list.Events[limit-1])
+		list.NextCursor = &nextCursor
+	}
+
+	return list, nil
+}
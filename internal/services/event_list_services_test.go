@@ -0,0 +1,127 @@
+package services
+
+import (
+	"Backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestEventListCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor eventListCursor
+	}{
+		{"sorted column", eventListCursor{Sort: "-start_date", Value: "2025-03-07T09:00:00Z", ID: 42}},
+		{"title with special characters", eventListCursor{Sort: "title", Value: "Café & \"Talks\" /?+=", ID: 7}},
+		{"relevance", eventListCursor{Sort: "relevance", Offset: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeEventListCursor(tt.cursor)
+			decoded, err := decodeEventListCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeEventListCursor(%q) returned %v", encoded, err)
+			}
+			if *decoded != tt.cursor {
+				t.Errorf("decodeEventListCursor(encodeEventListCursor(%+v)) = %+v", tt.cursor, *decoded)
+			}
+		})
+	}
+}
+
+func TestDecodeEventListCursorInvalid(t *testing.T) {
+	for _, value := range []string{"not base64!", "bm90IGpzb24"} {
+		if _, err := decodeEventListCursor(value); err == nil {
+			t.Errorf("decodeEventListCursor(%q) returned no error", value)
+		}
+	}
+}
+
+func TestEventListCursorAfter(t *testing.T) {
+	last := &models.Event{
+		ID:        9,
+		Title:     "Career Fair",
+		StartDate: time.Date(2025, 3, 7, 9, 0, 0, 0, time.UTC),
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		want   eventListCursor
+	}{
+		{
+			name:   "newest first by default",
+			params: map[string]string{},
+			want:   eventListCursor{Sort: "-created_at", Value: "2025-01-02T03:04:05.000000006Z", ID: 9},
+		},
+		{
+			name:   "start date",
+			params: map[string]string{"sort": "start_date"},
+			want:   eventListCursor{Sort: "start_date", Value: "2025-03-07T09:00:00Z", ID: 9},
+		},
+		{
+			name:   "title",
+			params: map[string]string{"sort": "-title"},
+			want:   eventListCursor{Sort: "-title", Value: "Career Fair", ID: 9},
+		},
+		{
+			name:   "relevance continues at an offset",
+			params: map[string]string{"search": "career", "limit": "5", "page": "3"},
+			want:   eventListCursor{Sort: "relevance", Offset: 15},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseEventListFilter(tt.params)
+			if err != nil {
+				t.Fatalf("parseEventListFilter(%v) returned %v", tt.params, err)
+			}
+
+			encoded := eventListCursorAfter(filter, last)
+			cursor, err := decodeEventListCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeEventListCursor(%q) returned %v", encoded, err)
+			}
+			if *cursor != tt.want {
+				t.Errorf("eventListCursorAfter() = %+v, want %+v", *cursor, tt.want)
+			}
+
+			// The cursor is accepted back by a list with the same sort order
+			next := map[string]string{"cursor": encoded}
+			for key, value := range tt.params {
+				if key != "page" {
+					next[key] = value
+				}
+			}
+			if _, err := parseEventListFilter(next); err != nil {
+				t.Errorf("parseEventListFilter() of the next page returned %v", err)
+			}
+		})
+	}
+}
+
+func TestParseEventListFilterCursorErrors(t *testing.T) {
+	startDate := encodeEventListCursor(eventListCursor{Sort: "start_date", Value: "2025-03-07T09:00:00Z", ID: 1})
+
+	tests := []struct {
+		name   string
+		params map[string]string
+	}{
+		{"invalid cursor", map[string]string{"cursor": "%%%"}},
+		{"other sort order", map[string]string{"cursor": startDate, "sort": "-start_date"}},
+		{"cursor and page", map[string]string{"cursor": startDate, "sort": "start_date", "page": "2"}},
+		{"negative offset", map[string]string{"cursor": encodeEventListCursor(eventListCursor{Sort: "relevance", Offset: -1}), "search": "fair"}},
+		{"drafts", map[string]string{"status": "published,draft"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseEventListFilter(tt.params); err == nil {
+				t.Errorf("parseEventListFilter(%v) returned no error", tt.params)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"Backend/configs"
+	"Backend/internal/database/app"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultEventReminders are sent when EVENT_REMINDERS is not set
+	defaultEventReminders = "24h,1h"
+	// eventReminderInterval is how often due reminders are looked up
+	eventReminderInterval = time.Minute
+)
+
+// eventReminder is a reminder sent a fixed duration before the start of an event,
+// the label identifies it in the sent log
+type eventReminder struct {
+	Label  string
+	Before time.Duration
+}
+
+// parseEventReminders parses a comma separated list of durations such as "24h,1h", sorted from the shortest
+func parseEventReminders(value string) ([]eventReminder, error) {
+	var reminders []eventReminder
+	for _, label := range strings.Split(value, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+
+		before, err := time.ParseDuration(label)
+		if err != nil || before <= 0 {
+			return nil, fmt.Errorf("invalid event reminder %q", label)
+		}
+		reminders = append(reminders, eventReminder{Label: label, Before: before})
+	}
+
+	sort.Slice(reminders, func(i, j int) bool {
+		return reminders[i].Before < reminders[j].Before
+	})
+	return reminders, nil
+}
+
+type EventReminderService struct {
+	EmailService EmailService
+	reminders    []eventReminder
+}
+
+func NewEventReminderService(emailService EmailService) *EventReminderService {
+	value := configs.LoadConfig().EventReminders
+	if value == "" {
+		value = defaultEventReminders
+	}
+
+	reminders, err := parseEventReminders(value)
+	if err != nil {
+		log.Printf("EventReminderService: %v, using %s", err, defaultEventReminders)
+		reminders, _ = parseEventReminders(defaultEventReminders)
+	}
+
+	return &EventReminderService{EmailService: emailService, reminders: reminders}
+}
+
+// Run sends due reminders until ctx is cancelled. Every reminder is recorded before it is sent,
+// so restarts and several replicas never send the same reminder twice.
+func (rs *EventReminderService) Run(ctx context.Context) {
+	log.Println("EventReminderService: started")
+
+	ticker := time.NewTicker(eventReminderInterval)
+	defer ticker.Stop()
+
+	for {
+		rs.runOnce(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			log.Println("EventReminderService: stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce sends every reminder that is due at now. A reminder is only due until the next shorter one is,
+// so someone registering an hour before the start gets a single reminder instead of all of them.
+func (rs *EventReminderService) runOnce(ctx context.Context, now time.Time) {
+	from := now
+	for _, reminder := range rs.reminders {
+		until := now.Add(reminder.Before)
+		rs.sendReminders(ctx, reminder, from, until)
+		from = until
+	}
+}
+
+func (rs *EventReminderService) sendReminders(ctx context.Context, reminder eventReminder, from, until time.Time) {
+	recipients, err := app.ListDueEventReminders(ctx, reminder.Label, from, until)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Println("Error listing event reminders:", err)
+		}
+		return
+	}
+
+	sent := 0
+	for _, recipient := range recipients {
+		claimed, err := app.ClaimEventReminder(ctx, recipient.Event.ID, recipient.UserID, reminder.Label)
+		if err != nil {
+			log.Println("Error recording event reminder:", err)
+			continue
+		}
+		if !claimed {
+			// Already sent by another replica
+			continue
+		}
+
+		if err := rs.EmailService.SendEventReminderEmail(recipient.Email, recipient.FirstName, recipient.Event); err != nil {
+			log.Printf("Error sending %s reminder of event %d to %s: %v", reminder.Label, recipient.Event.ID, recipient.Email, err)
+			if err := app.ReleaseEventReminder(ctx, recipient.Event.ID, recipient.UserID, reminder.Label); err != nil {
+				log.Println("Error releasing event reminder:", err)
+			}
+			continue
+		}
+		sent++
+	}
+
+	if sent > 0 {
+		log.Printf("EventReminderService: %d %s reminders sent", sent, reminder.Label)
+	}
+}
+
+// GetEventReminderPreference tells whether a user receives event reminders
+func (es *EventService) GetEventReminderPreference(userID uuid.UUID) (bool, error) {
+	return app.GetEventRemindersEnabled(userID)
+}
+
+// SetEventReminderPreference turns event reminders on or off for a user
+func (es *EventService) SetEventReminderPreference(userID uuid.UUID, enabled bool) error {
+	return app.SetEventRemindersEnabled(userID, enabled)
+}
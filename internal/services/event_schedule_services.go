@@ -0,0 +1,93 @@
+package services
+
+import (
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// eventLocation returns the location of the timezone of an event, falling back to the default calendar timezone
+func eventLocation(event *models.Event) *time.Location {
+	if event.Timezone != "" {
+		if location, err := time.LoadLocation(event.Timezone); err == nil {
+			return location
+		}
+	}
+	return utils.CalendarLocation()
+}
+
+// formatEventSchedule describes when an event takes place in its own timezone, such as "Friday, 7 March 2025, 19:00 - 21:00 WIB"
+func formatEventSchedule(event *models.Event) string {
+	location := eventLocation(event)
+	start, end := event.StartDate.In(location), event.EndDate.In(location)
+	sameDay := start.Format("2006-01-02") == end.Format("2006-01-02")
+
+	if event.AllDay {
+		if sameDay {
+			return start.Format("Monday, 2 January 2006")
+		}
+		return fmt.Sprintf("%s - %s", start.Format("Monday, 2 January 2006"), end.Format("Monday, 2 January 2006"))
+	}
+
+	if sameDay {
+		return fmt.Sprintf("%s, %s - %s", start.Format("Monday, 2 January 2006"), start.Format("15:04"), end.Format("15:04 MST"))
+	}
+	return fmt.Sprintf("%s - %s", start.Format("Monday, 2 January 2006 15:04"), end.Format("Monday, 2 January 2006 15:04 MST"))
+}
+
+// normalizeEventSchedule validates the timezone, dates and location of an event.
+// All-day events cover whole days in the timezone of the event: their dates are taken as written
+// and run from the start of the first day to the end of the last one. Other events keep their exact times.
+func normalizeEventSchedule(event *models.Event) error {
+	if event.Timezone == "" {
+		event.Timezone = utils.CalendarTimeZone
+	}
+
+	location, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return utils.BadRequestError{Message: "Timezone must be an IANA timezone such as Asia/Jakarta"}
+	}
+
+	if event.StartDate.IsZero() || event.EndDate.IsZero() {
+		return utils.BadRequestError{Message: "Start Date and End Date are required"}
+	}
+
+	// Stored all-day events already cover whole days and are kept as they are
+	if event.AllDay && !isWholeDay(event.StartDate.In(location), event.EndDate.In(location)) {
+		start, end := event.StartDate, event.EndDate
+		event.StartDate = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, location)
+		event.EndDate = time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, 0, location)
+	}
+
+	if !event.EndDate.After(event.StartDate) {
+		return utils.BadRequestError{Message: "End Date must be after Start Date"}
+	}
+
+	event.Venue = trimOptional(event.Venue)
+	event.OnlineMeetingURL = trimOptional(event.OnlineMeetingURL)
+
+	if event.OnlineMeetingURL != nil {
+		meetingURL, err := url.Parse(*event.OnlineMeetingURL)
+		if err != nil || (meetingURL.Scheme != "http" && meetingURL.Scheme != "https") || meetingURL.Host == "" {
+			return utils.BadRequestError{Message: "Online meeting URL must be an http or https link"}
+		}
+	}
+
+	return nil
+}
+
+// trimOptional trims an optional text field, blank values become nil
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
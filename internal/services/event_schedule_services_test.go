@@ -0,0 +1,92 @@
+package services
+
+import (
+	"Backend/internal/models"
+	"testing"
+	"time"
+)
+
+func TestNormalizeEventSchedule(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		event     models.Event
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{
+			name: "timed event at midnight UTC keeps its times",
+			event: models.Event{
+				Timezone:  "Asia/Jakarta",
+				StartDate: time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 3, 7, 2, 0, 0, 0, time.UTC),
+			},
+			wantStart: time.Date(2025, 3, 7, 7, 0, 0, 0, jakarta),
+			wantEnd:   time.Date(2025, 3, 7, 9, 0, 0, 0, jakarta),
+		},
+		{
+			name: "all-day event covers the dates as written",
+			event: models.Event{
+				Timezone:  "Asia/Jakarta",
+				AllDay:    true,
+				StartDate: time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC),
+			},
+			wantStart: time.Date(2025, 3, 7, 0, 0, 0, 0, jakarta),
+			wantEnd:   time.Date(2025, 3, 8, 23, 59, 59, 0, jakarta),
+		},
+		{
+			name: "all-day event west of UTC keeps its date",
+			event: models.Event{
+				Timezone:  "America/New_York",
+				AllDay:    true,
+				StartDate: time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC),
+			},
+			wantStart: time.Date(2025, 3, 7, 0, 0, 0, 0, newYork),
+			wantEnd:   time.Date(2025, 3, 7, 23, 59, 59, 0, newYork),
+		},
+		{
+			name: "stored all-day event is kept",
+			event: models.Event{
+				Timezone:  "Asia/Jakarta",
+				AllDay:    true,
+				StartDate: time.Date(2025, 3, 6, 17, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 3, 7, 16, 59, 59, 0, time.UTC),
+			},
+			wantStart: time.Date(2025, 3, 7, 0, 0, 0, 0, jakarta),
+			wantEnd:   time.Date(2025, 3, 7, 23, 59, 59, 0, jakarta),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			if err := normalizeEventSchedule(&event); err != nil {
+				t.Fatalf("normalizeEventSchedule() error = %v", err)
+			}
+			if !event.StartDate.Equal(tt.wantStart) || !event.EndDate.Equal(tt.wantEnd) {
+				t.Errorf("normalizeEventSchedule() = %v - %v, want %v - %v", event.StartDate, event.EndDate, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestNormalizeEventScheduleRejectsEndBeforeStart(t *testing.T) {
+	event := models.Event{
+		Timezone:  "Asia/Jakarta",
+		StartDate: time.Date(2025, 3, 7, 10, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 3, 7, 9, 0, 0, 0, time.UTC),
+	}
+	if err := normalizeEventSchedule(&event); err == nil {
+		t.Error("normalizeEventSchedule() accepted an event ending before it starts")
+	}
+}
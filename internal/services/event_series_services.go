@@ -0,0 +1,244 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	EditScopeThisOccurrence = "this"
+	EditScopeAllFuture      = "future"
+)
+
+// seriesOccurrenceSlug keeps slugs of occurrences apart by adding the occurrence date in the timezone of the event,
+// numbered when another event than eventID uses it. Slugs in reserved are taken by occurrences not saved yet.
+func seriesOccurrenceSlug(title string, start time.Time, location *time.Location, eventID int, reserved map[string]bool) (string, error) {
+	base := utils.GenerateFriendlyURL(title)
+	if base == "" {
+		base = "event"
+	}
+
+	slug, err := uniqueSlug(base+"-"+start.In(location).Format("2006-01-02"), "event", func(slug string) (bool, error) {
+		if reserved[slug] {
+			return true, nil
+		}
+		return app.EventSlugTaken(slug, eventID)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	reserved[slug] = true
+	return slug, nil
+}
+
+// CreateEventSeries creates a series from a template event and a recurrence rule.
+// The template dates are those of the first occurrence, every occurrence keeps the same duration.
+func (es *EventService) CreateEventSeries(template *models.Event, recurrenceRule string, exceptionDates []string) (*models.EventSeries, error) {
+	if err := validateTeamSize(template); err != nil {
+		return nil, err
+	}
+
+	if err := normalizeEventSchedule(template); err != nil {
+		return nil, err
+	}
+
+	if err := initialEventStatus(template); err != nil {
+		return nil, err
+	}
+
+	rule, err := utils.ParseRecurrenceRule(recurrenceRule)
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions := make([]time.Time, 0, len(exceptionDates))
+	for _, date := range exceptionDates {
+		exception, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			return nil, utils.BadRequestError{Message: "Exceptions must be dates formatted as YYYY-MM-DD"}
+		}
+		exceptions = append(exceptions, exception)
+	}
+
+	// Occurrences are generated in the timezone of the event so they keep their local time of day
+	location := eventLocation(template)
+	starts := rule.Occurrences(template.StartDate.In(location), exceptions)
+	if len(starts) == 0 {
+		return nil, utils.BadRequestError{Message: "The recurrence rule does not produce any occurrence"}
+	}
+
+	duration := template.EndDate.Sub(template.StartDate)
+	occurrences := make([]*models.Event, 0, len(starts))
+	reserved := map[string]bool{}
+	for _, start := range starts {
+		occurrence := *template
+		occurrence.StartDate = start
+		occurrence.EndDate = start.Add(duration)
+		occurrence.Slug, err = seriesOccurrenceSlug(template.Title, start, location, 0, reserved)
+		if err != nil {
+			return nil, err
+		}
+		if occurrence.Status.IsDateDriven() {
+			occurrence.Status = models.EventStatusPublished
+		}
+		setEventStatus(&occurrence)
+		occurrences = append(occurrences, &occurrence)
+	}
+
+	series := &models.EventSeries{
+		Title:          template.Title,
+		RecurrenceRule: recurrenceRule,
+		Exceptions:     exceptions,
+		StartDate:      starts[0],
+		EndDate:        starts[0].Add(duration),
+		Timezone:       template.Timezone,
+		UserID:         template.UserID,
+		OrganizationID: template.OrganizationID,
+	}
+
+	if err := app.CreateEventSeries(series, occurrences); err != nil {
+		return nil, err
+	}
+
+	for _, occurrence := range occurrences {
+		if err := linkEventMedia(occurrence.ID, occurrence.Description); err != nil {
+			return nil, err
+		}
+	}
+
+	series.Occurrences = occurrences
+	return series, nil
+}
+
+// GetEventSeries retrieves a series with all of its occurrences
+func (es *EventService) GetEventSeries(seriesID int) (*models.EventSeries, error) {
+	series, err := app.GetEventSeriesByID(seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	series.Occurrences, err = app.ListSeriesOccurrences(seriesID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// RequireSeriesManager returns an UnauthorizedError unless the user may manage every occurrence of the series.
+// A series without occurrences left can only be managed by its author.
+func (es *EventService) RequireSeriesManager(seriesID int, userID uuid.UUID) error {
+	series, err := app.GetEventSeriesByID(seriesID)
+	if err != nil {
+		return err
+	}
+
+	occurrences, err := app.ListSeriesOccurrences(seriesID, 0)
+	if err != nil {
+		return err
+	}
+
+	if len(occurrences) == 0 && series.UserID != userID {
+		return utils.UnauthorizedError{Message: "Only the author of this series can manage it"}
+	}
+
+	for _, occurrence := range occurrences {
+		if err := es.RequireEventManager(occurrence.ID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddSeriesException excludes a date from a series, removing the occurrence on that date
+func (es *EventService) AddSeriesException(seriesID int, date string) error {
+	exception, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return utils.BadRequestError{Message: "Date must be formatted as YYYY-MM-DD"}
+	}
+
+	return app.AddSeriesException(seriesID, exception)
+}
+
+// DeleteEventSeries deletes a series and its upcoming occurrences, unless one of them has registrations
+func (es *EventService) DeleteEventSeries(seriesID int) error {
+	return app.DeleteEventSeries(seriesID)
+}
+
+// editSeriesOccurrence applies an edit to one occurrence, or with EditScopeAllFuture also to every later occurrence.
+// Later occurrences are moved by the same offset as the edited one so the rhythm of the series is kept.
+func (es *EventService) editSeriesOccurrence(eventID int, updatedEvent *models.Event, scope string) error {
+	original, err := app.GetEventByID(eventID)
+	if err != nil {
+		return err
+	}
+
+	location := eventLocation(updatedEvent)
+	titleChanged := updatedEvent.Title != original.Title
+	reserved := map[string]bool{}
+	if titleChanged {
+		updatedEvent.Slug, err = seriesOccurrenceSlug(updatedEvent.Title, updatedEvent.StartDate, location, eventID, reserved)
+		if err != nil {
+			return err
+		}
+	}
+
+	if scope == EditScopeThisOccurrence {
+		if err := app.UpdateEvent(eventID, updatedEvent); err != nil {
+			return err
+		}
+		return linkEventMedia(eventID, updatedEvent.Description)
+	}
+
+	occurrences, err := app.ListSeriesOccurrences(*updatedEvent.SeriesID, eventID)
+	if err != nil {
+		return err
+	}
+
+	shift := updatedEvent.StartDate.Sub(original.StartDate)
+	duration := updatedEvent.EndDate.Sub(updatedEvent.StartDate)
+
+	for i, occurrence := range occurrences {
+		if occurrence.ID == eventID {
+			occurrences[i] = updatedEvent
+			continue
+		}
+
+		occurrence.Title = updatedEvent.Title
+		occurrence.Description = updatedEvent.Description
+		occurrence.Thumbnail = updatedEvent.Thumbnail
+		occurrence.OrganizationID = updatedEvent.OrganizationID
+		occurrence.MaxRegistration = updatedEvent.MaxRegistration
+		occurrence.TeamRegistration = updatedEvent.TeamRegistration
+		occurrence.MinTeamSize = updatedEvent.MinTeamSize
+		occurrence.MaxTeamSize = updatedEvent.MaxTeamSize
+		occurrence.OpenForAll = updatedEvent.OpenForAll
+		occurrence.Timezone = updatedEvent.Timezone
+		occurrence.Venue = updatedEvent.Venue
+		occurrence.OnlineMeetingURL = updatedEvent.OnlineMeetingURL
+		occurrence.StartDate = occurrence.StartDate.Add(shift)
+		occurrence.EndDate = occurrence.StartDate.Add(duration)
+		if titleChanged {
+			occurrence.Slug, err = seriesOccurrenceSlug(occurrence.Title, occurrence.StartDate, location, occurrence.ID, reserved)
+			if err != nil {
+				return err
+			}
+		}
+		setEventStatus(occurrence)
+	}
+
+	if err := app.UpdateSeriesOccurrences(*updatedEvent.SeriesID, updatedEvent.Title, occurrences); err != nil {
+		return err
+	}
+
+	for _, occurrence := range occurrences {
+		if err := linkEventMedia(occurrence.ID, occurrence.Description); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,32 +3,92 @@ package services
 import (
 	"Backend/internal/database/app"
 	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"fmt"
 	"github.com/google/uuid"
 	"time"
 )
 
 type EventService struct {
+	EmailService EmailService
+	Storage      *S3Service
 }
 
-func NewEventService() *EventService {
-	return &EventService{}
+func NewEventService(emailService EmailService, storage *S3Service) *EventService {
+	return &EventService{EmailService: emailService, Storage: storage}
+}
+
+// validateTeamSize checks the team size settings of a team-based event
+func validateTeamSize(event *models.Event) error {
+	if !event.TeamRegistration {
+		return nil
+	}
+
+	if event.MinTeamSize == nil || *event.MinTeamSize < 1 {
+		return utils.BadRequestError{Message: "Minimum team size must be at least 1"}
+	}
+
+	if event.MaxTeamSize != nil && *event.MaxTeamSize < *event.MinTeamSize {
+		return utils.BadRequestError{Message: "Maximum team size cannot be less than minimum team size"}
+	}
+
+	return nil
+}
+
+// setEventStatus keeps the status of a published event in line with its dates,
+// drafts, cancelled and postponed events keep their status
+func setEventStatus(event *models.Event) {
+	if event.Status.IsDateDriven() {
+		event.Status = models.EventStatusForDates(event.StartDate, event.EndDate, time.Now())
+	}
+}
+
+// initialEventStatus checks the status a new event is created with, events are published unless created as draft
+func initialEventStatus(event *models.Event) error {
+	switch event.Status {
+	case "":
+		event.Status = models.EventStatusPublished
+	case models.EventStatusDraft, models.EventStatusPublished:
+	default:
+		return utils.BadRequestError{Message: "New events can only be created as draft or published"}
+	}
+
+	setEventStatus(event)
+	return nil
+}
+
+// requireRegistrationOpen returns an error when an event does not accept registrations in its current status
+func requireRegistrationOpen(eventID int) error {
+	event, err := app.GetEventByID(eventID)
+	if err != nil {
+		return err
+	}
+
+	if !event.Status.AcceptsRegistrations() {
+		return utils.BadRequestError{Message: fmt.Sprintf("Registration is not possible for an event that is %s", event.Status)}
+	}
+	return nil
 }
 
 // CreateEvent creates a new event in the database
 func (es *EventService) CreateEvent(event *models.Event) error {
-	if time.Now().Before(event.StartDate) {
-		event.Status = "Upcoming"
-	} else if time.Now().After(event.StartDate) && time.Now().Before(event.EndDate) {
-		event.Status = "Open"
-	} else {
-		event.Status = "Ended"
+	if err := validateTeamSize(event); err != nil {
+		return err
+	}
+
+	if err := normalizeEventSchedule(event); err != nil {
+		return err
+	}
+
+	if err := initialEventStatus(event); err != nil {
+		return err
 	}
 
 	if err := app.CreateEvent(event); err != nil {
 		return err
 	}
 
-	return nil
+	return linkEventMedia(event.ID, event.Description)
 }
 
 // GetEventByID retrieves an event by its ID
@@ -37,33 +97,65 @@ func (es *EventService) GetEventByID(eventID int) (*models.Event, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if err := attachEventHosts(event); err != nil {
+		return nil, err
+	}
 	return event, nil
 }
 
-// GetEventBySlug retrieves an event by its slug
-func (es *EventService) GetEventBySlug(slug string) (*models.Event, error) {
-	event, err := app.GetEventBySlug(slug)
+// GetEventBySlug retrieves an event by its current slug or one it had before being renamed.
+// Drafts are only found by those who may manage them, viewerID is uuid.Nil for visitors.
+func (es *EventService) GetEventBySlug(slug string, viewerID uuid.UUID) (*models.Event, error) {
+	event, err := findEventBySlug(slug)
 	if err != nil {
 		return nil, err
 	}
+
+	if event.Status == models.EventStatusDraft {
+		allowed := false
+		if viewerID != uuid.Nil {
+			if allowed, err = app.CanManageEvent(event.ID, viewerID); err != nil {
+				return nil, err
+			}
+		}
+		if !allowed {
+			return nil, &utils.NotFoundError{Message: "Event not found"}
+		}
+	}
+
+	if err := attachEventHosts(event); err != nil {
+		return nil, err
+	}
 	return event, nil
 }
 
-// EditEvent updates an event in the database
-func (es *EventService) EditEvent(eventID int, updatedEvent *models.Event) error {
-	if time.Now().Before(updatedEvent.StartDate) {
-		updatedEvent.Status = "Upcoming"
-	} else if time.Now().After(updatedEvent.StartDate) && time.Now().Before(updatedEvent.EndDate) {
-		updatedEvent.Status = "Open"
-	} else {
-		updatedEvent.Status = "Ended"
+// EditEvent updates an event in the database. For occurrences of a series the scope tells
+// whether only this occurrence (EditScopeThisOccurrence) or also every later one (EditScopeAllFuture) changes.
+func (es *EventService) EditEvent(eventID int, updatedEvent *models.Event, scope string) error {
+	if err := validateTeamSize(updatedEvent); err != nil {
+		return err
+	}
+
+	if scope != EditScopeThisOccurrence && scope != EditScopeAllFuture {
+		return utils.BadRequestError{Message: "Scope must be this or future"}
+	}
+
+	if err := normalizeEventSchedule(updatedEvent); err != nil {
+		return err
+	}
+
+	setEventStatus(updatedEvent)
+
+	if updatedEvent.SeriesID != nil {
+		return es.editSeriesOccurrence(eventID, updatedEvent, scope)
 	}
 
 	if err := app.UpdateEvent(eventID, updatedEvent); err != nil {
 		return err
 	}
 
-	return nil
+	return linkEventMedia(eventID, updatedEvent.Description)
 }
 
 // DeleteEvent deletes an event from the database
@@ -75,19 +167,21 @@ func (es *EventService) DeleteEvent(eventID int) error {
 	return nil
 }
 
-// ListEvents retrieves all events from the database
-func (es *EventService) ListEvents(queryParams map[string]string) ([]*models.Event, int, error) {
-	events, totalPages, err := app.ListEvents(queryParams)
-	if err != nil {
-		return nil, totalPages, err
+// RegisterForEvent registers a user for an event
+func (es *EventService) RegisterForEvent(userID uuid.UUID, eventID int, additionalNotes string, formAnswers map[string]string) error {
+	if err := requireRegistrationOpen(eventID); err != nil {
+		return err
 	}
 
-	return events, totalPages, nil
-}
+	if err := requireEligibility(eventID, userID); err != nil {
+		return err
+	}
 
-// RegisterForEvent registers a user for an event
-func (es *EventService) RegisterForEvent(userID uuid.UUID, eventID int, additionalNotes string) error {
-	if err := app.RegisterForEvent(userID, eventID, additionalNotes); err != nil {
+	if formAnswers == nil {
+		formAnswers = map[string]string{}
+	}
+
+	if err := app.RegisterForEvent(userID, eventID, additionalNotes, formAnswers); err != nil {
 		return err
 	}
 
@@ -122,3 +216,8 @@ func (es *EventService) TotalRegisteredUsers(eventID int) (int, error) {
 
 	return total, nil
 }
+
+// CheckInRegistration marks a registered user as present at the event
+func (es *EventService) CheckInRegistration(eventID int, userID uuid.UUID) (*models.EventRegistration, error) {
+	return app.CheckInRegistration(eventID, userID)
+}
@@ -1,11 +1,20 @@
 package services
 
 import (
-	"Backend/internal/models"
+	"Backend/internal/database/app"
+	"context"
+	"errors"
 	"log"
 	"time"
 )
 
+const (
+	// maxStatusCheckInterval bounds the wait between runs, so events created or moved in the meantime are picked up
+	maxStatusCheckInterval = 5 * time.Minute
+	// minStatusCheckInterval keeps a burst of transitions from turning into a busy loop
+	minStatusCheckInterval = time.Second
+)
+
 type EventStatusUpdater struct {
 	EventService *EventService
 }
@@ -14,46 +23,64 @@ func NewEventStatusUpdater(eventService *EventService) *EventStatusUpdater {
 	return &EventStatusUpdater{EventService: eventService}
 }
 
-func (e *EventStatusUpdater) Run() {
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
+// Run advances event statuses until ctx is cancelled. Instead of polling every event it sleeps
+// until the next start or end date, and several replicas may run it safely thanks to an advisory lock.
+func (e *EventStatusUpdater) Run(ctx context.Context) {
+	log.Println("EventStatusUpdater: started")
 
 	for {
+		wait := e.runOnce(ctx)
+
+		timer := time.NewTimer(wait)
 		select {
-		case <-ticker.C:
-			log.Println("EventStatusUpdater: Status update loop started")
-			// Get all events
-			events, _, err := e.EventService.ListEvents(map[string]string{})
-			if err != nil {
-				// Log the error
-				log.Println("Error fetching events:", err)
-				continue
-			}
-
-			// Update event status
-			for _, event := range events {
-				// Update status based on current time
-				e.updateEventStatus(event)
-			}
+		case <-ctx.Done():
+			timer.Stop()
+			log.Println("EventStatusUpdater: stopped")
+			return
+		case <-timer.C:
 		}
 	}
 }
 
-func (e *EventStatusUpdater) updateEventStatus(event *models.Event) {
-	currentTime := time.Now()
+// runOnce applies due transitions and returns how long to wait before the next run
+func (e *EventStatusUpdater) runOnce(ctx context.Context) time.Duration {
+	now := time.Now()
+
+	acquired, changed, err := app.AdvanceEventStatuses(ctx, now)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Println("Error updating event status:", err)
+		}
+		return maxStatusCheckInterval
+	}
+
+	if !acquired {
+		// Another replica is advancing statuses, it will handle the transitions
+		return maxStatusCheckInterval
+	}
+
+	if changed > 0 {
+		log.Printf("EventStatusUpdater: %d event statuses updated", changed)
+	}
 
-	switch {
-	case currentTime.Before(event.StartDate):
-		event.Status = "Upcoming"
-	case currentTime.After(event.StartDate) && currentTime.Before(event.EndDate):
-		event.Status = "Open"
-	default:
-		event.Status = "Closed"
+	next, err := app.NextEventStatusTransition(ctx, now)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Println("Error finding next event status transition:", err)
+		}
+		return maxStatusCheckInterval
 	}
 
-	// Update the event status in the database
-	if err := e.EventService.EditEvent(event.ID, event); err != nil {
-		// Log the error
-		log.Println("Error updating event status:", err)
+	if next == nil {
+		return maxStatusCheckInterval
+	}
+
+	wait := time.Until(*next)
+	if wait < minStatusCheckInterval {
+		return minStatusCheckInterval
+	}
+	if wait > maxStatusCheckInterval {
+		return maxStatusCheckInterval
 	}
+	return wait
 }
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"log"
+	"time"
+)
+
+// TransitionEventStatus moves an event to another status on behalf of an organizer.
+// Publishing places the event in the published, open or ended status matching its dates.
+// Registrants are notified by email when an event is cancelled or postponed.
+func (es *EventService) TransitionEventStatus(eventID int, next models.EventStatus, reason string) (*models.Event, error) {
+	if !next.IsValid() {
+		return nil, utils.BadRequestError{Message: fmt.Sprintf("Unknown event status: %s", next)}
+	}
+
+	event, err := app.GetEventByID(eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &utils.NotFoundError{Message: "Event not found"}
+		}
+		return nil, err
+	}
+
+	if !event.Status.CanTransitionTo(next) {
+		return nil, utils.BadRequestError{Message: fmt.Sprintf("An event cannot go from %s to %s", event.Status, next)}
+	}
+
+	if next == models.EventStatusPublished {
+		next = models.EventStatusForDates(event.StartDate, event.EndDate, time.Now())
+	}
+
+	if err := app.UpdateEventStatus(eventID, event.Status, next); err != nil {
+		return nil, err
+	}
+	event.Status = next
+
+	if next == models.EventStatusCancelled || next == models.EventStatusPostponed {
+		go es.notifyRegistrants(event, reason)
+	}
+
+	return event, nil
+}
+
+// notifyRegistrants emails every registrant about the new status of an event
+func (es *EventService) notifyRegistrants(event *models.Event, reason string) {
+	if es.EmailService == nil {
+		return
+	}
+
+	users, err := app.ListRegisteredUsers(event.ID)
+	if err != nil {
+		log.Printf("Error listing registrants of event %d for notification: %v", event.ID, err)
+		return
+	}
+
+	for _, user := range users {
+		if err := es.EmailService.SendEventStatusEmail(user.Email, user.FirstName, event, reason); err != nil {
+			log.Printf("Error notifying %s about event %d: %v", user.Email, event.ID, err)
+		}
+	}
+}
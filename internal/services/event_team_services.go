@@ -0,0 +1,165 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"github.com/google/uuid"
+	"strings"
+)
+
+type TeamService struct {
+}
+
+func NewTeamService() *TeamService {
+	return &TeamService{}
+}
+
+// CreateTeam creates a team for an event with the creator as captain
+func (ts *TeamService) CreateTeam(team *models.EventTeam) error {
+	team.Name = strings.TrimSpace(team.Name)
+	if team.Name == "" {
+		return utils.BadRequestError{Message: "Team name is required"}
+	}
+	if len(team.Name) > 100 {
+		return utils.BadRequestError{Message: "Team name cannot be longer than 100 characters"}
+	}
+
+	if err := requireEligibility(team.EventID, team.CaptainID); err != nil {
+		return err
+	}
+
+	if err := app.CreateTeam(team); err != nil {
+		return err
+	}
+
+	created, err := app.GetTeamByID(team.ID)
+	if err != nil {
+		return err
+	}
+	*team = *created
+
+	return nil
+}
+
+// GetTeam retrieves a team and makes sure it belongs to the event
+func (ts *TeamService) GetTeam(eventID, teamID int) (*models.EventTeam, error) {
+	team, err := app.GetTeamByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.EventID != eventID {
+		return nil, &utils.NotFoundError{Message: "Team not found"}
+	}
+
+	return team, nil
+}
+
+// GetTeamByEventAndUser retrieves the team a user belongs to for an event
+func (ts *TeamService) GetTeamByEventAndUser(eventID int, userID uuid.UUID) (*models.EventTeam, error) {
+	return app.GetTeamByEventAndUser(eventID, userID)
+}
+
+// ListTeamsByEvent retrieves all teams of an event
+func (ts *TeamService) ListTeamsByEvent(eventID int) ([]*models.EventTeam, error) {
+	return app.ListTeamsByEvent(eventID)
+}
+
+// InviteMember lets the team captain invite a user by username or email
+func (ts *TeamService) InviteMember(eventID, teamID int, captainID uuid.UUID, usernameOrEmail string) (*models.EventTeamInvitation, error) {
+	if strings.TrimSpace(usernameOrEmail) == "" {
+		return nil, utils.BadRequestError{Message: "Username or email is required"}
+	}
+
+	team, err := ts.GetTeam(eventID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.CaptainID != captainID {
+		return nil, utils.UnauthorizedError{Message: "Only the team captain can invite members"}
+	}
+
+	return app.CreateTeamInvitation(teamID, captainID, usernameOrEmail)
+}
+
+// ListInvitationsByUser retrieves the pending team invitations of a user
+func (ts *TeamService) ListInvitationsByUser(userID uuid.UUID) ([]*models.EventTeamInvitation, error) {
+	return app.ListTeamInvitationsByUser(userID)
+}
+
+// RespondToInvitation accepts or declines a team invitation
+func (ts *TeamService) RespondToInvitation(invitationID int, userID uuid.UUID, accept bool) (*models.EventTeamInvitation, error) {
+	if accept {
+		invitation, err := app.GetTeamInvitationByID(invitationID)
+		if err != nil {
+			return nil, err
+		}
+
+		if invitation.InviteeID == userID {
+			if err := requireEligibility(invitation.EventID, userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return app.RespondToTeamInvitation(invitationID, userID, accept)
+}
+
+// RemoveMember removes a member from a team, members may remove themselves and the captain may remove anyone
+func (ts *TeamService) RemoveMember(eventID, teamID int, actorID, memberID uuid.UUID) error {
+	team, err := ts.GetTeam(eventID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if actorID != memberID && team.CaptainID != actorID {
+		return utils.UnauthorizedError{Message: "Only the team captain can remove other members"}
+	}
+
+	return app.RemoveTeamMember(teamID, memberID)
+}
+
+// DisbandTeam deletes a team and its registration, only the captain may disband a team
+func (ts *TeamService) DisbandTeam(eventID, teamID int, captainID uuid.UUID) error {
+	team, err := ts.GetTeam(eventID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if team.CaptainID != captainID {
+		return utils.UnauthorizedError{Message: "Only the team captain can disband the team"}
+	}
+
+	return app.DeleteTeam(teamID)
+}
+
+// RegisterTeam registers a team for its event, only the captain may register the team
+func (ts *TeamService) RegisterTeam(eventID, teamID int, captainID uuid.UUID, additionalNotes string) (*models.EventTeam, error) {
+	team, err := ts.GetTeam(eventID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if team.CaptainID != captainID {
+		return nil, utils.UnauthorizedError{Message: "Only the team captain can register the team"}
+	}
+
+	if err := requireRegistrationOpen(eventID); err != nil {
+		return nil, err
+	}
+
+	// Rules may have changed since members joined, so every member is checked again
+	for _, member := range team.Members {
+		if err := requireEligibility(eventID, member.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := app.RegisterTeam(teamID, additionalNotes); err != nil {
+		return nil, err
+	}
+
+	return app.GetTeamByID(teamID)
+}
@@ -0,0 +1,355 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	eventThumbnailDirectory         = "event"
+	eventTemplateThumbnailDirectory = "event-templates"
+	maxEventTemplateNameLength      = 255
+)
+
+// parseCopyStartDate parses the start date of a copied event. A bare date is taken in the timezone
+// of the event at the given time of day, timestamps are used as they are.
+func parseCopyStartDate(value string, location *time.Location, timeOfDay time.Time) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, location); err == nil {
+		return time.Date(date.Year(), date.Month(), date.Day(), timeOfDay.Hour(), timeOfDay.Minute(), timeOfDay.Second(), 0, location), nil
+	}
+
+	start, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, utils.BadRequestError{Message: "start_date must be a date formatted as YYYY-MM-DD or an RFC 3339 timestamp"}
+	}
+	return start, nil
+}
+
+// copyEventEligibility returns the eligibility rules of an event to copy, nil when it has none
+func copyEventEligibility(eventID int) (*models.EventEligibility, error) {
+	rules, err := app.GetEventEligibility(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rules.UpdatedAt.IsZero() {
+		return nil, nil
+	}
+	rules.EventID = 0
+	return rules, nil
+}
+
+// eventCoHostIDs returns the organizations co-hosting an event
+func eventCoHostIDs(eventID int) ([]int, error) {
+	hosts, err := app.ListEventHosts([]int{eventID})
+	if err != nil {
+		return nil, err
+	}
+
+	coHosts := []int{}
+	for _, host := range hosts[eventID] {
+		if !host.IsPrimary {
+			coHosts = append(coHosts, host.OrganizationID)
+		}
+	}
+	return coHosts, nil
+}
+
+// copyThumbnail copies a thumbnail stored in R2 to a new key and returns its URL. Thumbnails that are not
+// in R2, such as those of older events, cannot be copied and the fallback URL is shared instead.
+func (es *EventService) copyThumbnail(ctx context.Context, fromDirectory, fromSlug, toDirectory, toSlug, fallback string) string {
+	if es.Storage == nil {
+		return fallback
+	}
+
+	if err := es.Storage.CopyFileR2(ctx, fromDirectory, fromSlug, toDirectory, toSlug); err != nil {
+		log.Printf("Thumbnail %s/%s could not be copied, sharing it instead: %v", fromDirectory, fromSlug, err)
+		return fallback
+	}
+
+	thumbnail, _ := es.Storage.GetFileR2(toDirectory, toSlug)
+	return thumbnail
+}
+
+// createEventCopy validates and stores an event created from another event or a template, as a draft
+// so organizers can review it before publishing. The thumbnail is copied from the given R2 key.
+func (es *EventService) createEventCopy(ctx context.Context, event *models.Event, thumbnailDirectory, thumbnailSlug, fallbackThumbnail string, eligibility *models.EventEligibility, coHosts []int) error {
+	event.Status = models.EventStatusDraft
+
+	if err := validateTeamSize(event); err != nil {
+		return err
+	}
+	if err := normalizeEventSchedule(event); err != nil {
+		return err
+	}
+	if err := initialEventStatus(event); err != nil {
+		return err
+	}
+
+	slug, err := uniqueEventSlug(event.Title, 0)
+	if err != nil {
+		return err
+	}
+	event.Slug = slug
+
+	event.Thumbnail = es.copyThumbnail(ctx, thumbnailDirectory, thumbnailSlug, eventThumbnailDirectory, event.Slug, fallbackThumbnail)
+	copied := event.Thumbnail != fallbackThumbnail
+
+	if err := app.CreateEventCopy(event, eligibility, coHosts); err != nil {
+		if copied {
+			if err := es.Storage.DeleteFile(ctx, eventThumbnailDirectory, event.Slug); err != nil {
+				log.Println("Error deleting copied thumbnail:", err)
+			}
+		}
+		return err
+	}
+
+	if err := linkEventMedia(event.ID, event.Description); err != nil {
+		return err
+	}
+	return attachEventHosts(event)
+}
+
+// DuplicateEvent creates a draft copy of an event with its registration settings, eligibility rules and co-hosts.
+// The copy starts on request.StartDate, or request.ShiftDays days after the original in its timezone.
+func (es *EventService) DuplicateEvent(ctx context.Context, eventID int, userID uuid.UUID, request *models.EventCopyRequest) (*models.Event, error) {
+	source, err := app.GetEventByID(eventID)
+	if err != nil {
+		return nil, &utils.NotFoundError{Message: "Event not found"}
+	}
+
+	location := eventLocation(source)
+	localStart, localEnd := source.StartDate.In(location), source.EndDate.In(location)
+
+	var start, end time.Time
+	switch {
+	case request.StartDate != "" && request.ShiftDays != 0:
+		return nil, utils.BadRequestError{Message: "Provide either start_date or shift_days"}
+	case request.StartDate != "":
+		start, err = parseCopyStartDate(request.StartDate, location, localStart)
+		if err != nil {
+			return nil, err
+		}
+		end = start.Add(source.EndDate.Sub(source.StartDate))
+	case request.ShiftDays != 0:
+		// Shifting by calendar days keeps the time of day across daylight saving changes
+		start, end = localStart.AddDate(0, 0, request.ShiftDays), localEnd.AddDate(0, 0, request.ShiftDays)
+	default:
+		return nil, utils.BadRequestError{Message: "Provide start_date or shift_days for the copy"}
+	}
+
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		title = source.Title
+	}
+
+	eligibility, err := copyEventEligibility(eventID)
+	if err != nil {
+		return nil, err
+	}
+	coHosts, err := eventCoHostIDs(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &models.Event{
+		Title:            title,
+		Description:      source.Description,
+		StartDate:        start,
+		EndDate:          end,
+		AllDay:           source.AllDay,
+		UserID:           userID,
+		OrganizationID:   source.OrganizationID,
+		MaxRegistration:  source.MaxRegistration,
+		TeamRegistration: source.TeamRegistration,
+		MinTeamSize:      source.MinTeamSize,
+		MaxTeamSize:      source.MaxTeamSize,
+		OpenForAll:       source.OpenForAll,
+		Timezone:         source.Timezone,
+		Venue:            source.Venue,
+		OnlineMeetingURL: source.OnlineMeetingURL,
+	}
+
+	if err := es.createEventCopy(ctx, event, eventThumbnailDirectory, source.Slug, source.Thumbnail, eligibility, coHosts); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// SaveEventTemplate saves an event as a named template of its primary host organization
+func (es *EventService) SaveEventTemplate(ctx context.Context, eventID int, userID uuid.UUID, name string) (*models.EventTemplate, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, utils.BadRequestError{Message: "Template name is required"}
+	}
+	if len(name) > maxEventTemplateNameLength {
+		return nil, utils.BadRequestError{Message: fmt.Sprintf("Template name cannot be longer than %d characters", maxEventTemplateNameLength)}
+	}
+
+	source, err := app.GetEventByID(eventID)
+	if err != nil {
+		return nil, &utils.NotFoundError{Message: "Event not found"}
+	}
+
+	eligibility, err := copyEventEligibility(eventID)
+	if err != nil {
+		return nil, err
+	}
+	coHosts, err := eventCoHostIDs(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	location := eventLocation(source)
+	template := &models.EventTemplate{
+		Name:           name,
+		OrganizationID: source.OrganizationID,
+		Organization:   source.Organization,
+		UserID:         userID,
+		Settings: models.EventTemplateSettings{
+			Title:                 source.Title,
+			Description:           source.Description,
+			StartTime:             source.StartDate.In(location).Format("15:04:05"),
+			DurationSeconds:       int64(source.EndDate.Sub(source.StartDate) / time.Second),
+			AllDay:                source.AllDay,
+			Timezone:              location.String(),
+			Venue:                 source.Venue,
+			OnlineMeetingURL:      source.OnlineMeetingURL,
+			MaxRegistration:       source.MaxRegistration,
+			TeamRegistration:      source.TeamRegistration,
+			MinTeamSize:           source.MinTeamSize,
+			MaxTeamSize:           source.MaxTeamSize,
+			OpenForAll:            source.OpenForAll,
+			Eligibility:           eligibility,
+			CoHostOrganizationIDs: coHosts,
+		},
+	}
+
+	if err := app.CreateEventTemplate(template); err != nil {
+		return nil, err
+	}
+
+	// The thumbnail is copied so the template outlives the event it was saved from
+	thumbnail := es.copyThumbnail(ctx, eventThumbnailDirectory, source.Slug, eventTemplateThumbnailDirectory, strconv.Itoa(template.ID), source.Thumbnail)
+	if thumbnail != "" {
+		if err := app.SetEventTemplateThumbnail(template.ID, thumbnail); err != nil {
+			return nil, err
+		}
+		template.Thumbnail = &thumbnail
+	}
+
+	return template, nil
+}
+
+// CreateEventFromTemplate creates a draft event from a template, starting on request.StartDate
+func (es *EventService) CreateEventFromTemplate(ctx context.Context, templateID int, userID uuid.UUID, request *models.EventCopyRequest) (*models.Event, error) {
+	template, err := app.GetEventTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	settings := template.Settings
+
+	if request.StartDate == "" {
+		return nil, utils.BadRequestError{Message: "start_date is required"}
+	}
+	if request.ShiftDays != 0 {
+		return nil, utils.BadRequestError{Message: "shift_days only applies to duplicated events"}
+	}
+
+	location, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		location = utils.CalendarLocation()
+	}
+	timeOfDay, err := time.Parse("15:04:05", settings.StartTime)
+	if err != nil {
+		return nil, fmt.Errorf("template %d has an invalid start time %q", templateID, settings.StartTime)
+	}
+
+	start, err := parseCopyStartDate(request.StartDate, location, timeOfDay)
+	if err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(request.Title)
+	if title == "" {
+		title = settings.Title
+	}
+
+	event := &models.Event{
+		Title:            title,
+		Description:      settings.Description,
+		StartDate:        start,
+		EndDate:          start.Add(time.Duration(settings.DurationSeconds) * time.Second),
+		AllDay:           settings.AllDay,
+		UserID:           userID,
+		OrganizationID:   template.OrganizationID,
+		MaxRegistration:  settings.MaxRegistration,
+		TeamRegistration: settings.TeamRegistration,
+		MinTeamSize:      settings.MinTeamSize,
+		MaxTeamSize:      settings.MaxTeamSize,
+		OpenForAll:       settings.OpenForAll,
+		Timezone:         location.String(),
+		Venue:            settings.Venue,
+		OnlineMeetingURL: settings.OnlineMeetingURL,
+	}
+
+	fallbackThumbnail := ""
+	if template.Thumbnail != nil {
+		fallbackThumbnail = *template.Thumbnail
+	}
+
+	err = es.createEventCopy(ctx, event, eventTemplateThumbnailDirectory, strconv.Itoa(template.ID), fallbackThumbnail, settings.Eligibility, settings.CoHostOrganizationIDs)
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// GetEventTemplate retrieves a template by its ID
+func (es *EventService) GetEventTemplate(templateID int) (*models.EventTemplate, error) {
+	return app.GetEventTemplate(templateID)
+}
+
+// ListEventTemplates retrieves the templates of an organization, organizationID 0 returns every template
+func (es *EventService) ListEventTemplates(organizationID int) ([]*models.EventTemplate, error) {
+	return app.ListEventTemplates(organizationID)
+}
+
+// DeleteEventTemplate deletes a template, which its author and the officers of its organization may do
+func (es *EventService) DeleteEventTemplate(ctx context.Context, templateID int, userID uuid.UUID) error {
+	template, err := app.GetEventTemplate(templateID)
+	if err != nil {
+		return err
+	}
+
+	if template.UserID != userID {
+		allowed, err := app.CanManageOrganization(template.OrganizationID, userID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return utils.UnauthorizedError{Message: "Only officers of " + template.Organization + " can delete this template"}
+		}
+	}
+
+	if err := app.DeleteEventTemplate(templateID); err != nil {
+		return err
+	}
+
+	if es.Storage != nil {
+		if exists, _ := es.Storage.FileExists(ctx, eventTemplateThumbnailDirectory, strconv.Itoa(templateID)); exists {
+			if err := es.Storage.DeleteFile(ctx, eventTemplateThumbnailDirectory, strconv.Itoa(templateID)); err != nil {
+				log.Println("Error deleting template thumbnail:", err)
+			}
+		}
+	}
+	return nil
+}
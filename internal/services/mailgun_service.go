@@ -3,6 +3,7 @@ package services
 import (
 	"Backend/configs"
 	"Backend/internal/database/app"
+	"Backend/internal/models"
 	"context"
 	"github.com/google/uuid"
 	"github.com/mailgun/mailgun-go/v4"
@@ -690,6 +691,20 @@ to developer@computizen.me</p>
 	return nil
 }
 
+// SendEventStatusEmail tells a registrant that an event was cancelled or postponed
+func (ms *MailgunService) SendEventStatusEmail(to, name string, event *models.Event, reason string) error {
+	subject, body := generateEventStatusEmail(name, event, reason)
+
+	return ms.sendEmail(to, subject, body)
+}
+
+// SendEventReminderEmail reminds a registrant of an upcoming event
+func (ms *MailgunService) SendEventReminderEmail(to, name string, event *models.Event) error {
+	subject, body := generateEventReminderEmail(name, event)
+
+	return ms.sendEmail(to, subject, body)
+}
+
 func (ms *MailgunService) sendEmail(toEmail, subject, body string) error {
 	message := ms.mailgun.NewMessage(
 		ms.senderEmail,
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	// mediaGracePeriod keeps unreferenced media around for articles being written and edits being undone
+	mediaGracePeriod      = 24 * time.Hour
+	mediaCollectInterval  = time.Hour
+	mediaCollectBatchSize = 100
+)
+
+type MediaCollector struct {
+	MediaService *MediaService
+}
+
+func NewMediaCollector(mediaService *MediaService) *MediaCollector {
+	return &MediaCollector{MediaService: mediaService}
+}
+
+// Run removes the media no article nor revision references anymore every mediaCollectInterval until ctx is cancelled
+func (mc *MediaCollector) Run(ctx context.Context) {
+	log.Println("MediaCollector: started")
+
+	for {
+		mc.runOnce(ctx)
+
+		timer := time.NewTimer(mediaCollectInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.Println("MediaCollector: stopped")
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// runOnce removes the orphaned media batch by batch. Rows are deleted before the files so a media
+// being linked again is never lost, a file whose deletion fails is only logged.
+func (mc *MediaCollector) runOnce(ctx context.Context) {
+	collected := 0
+	for {
+		mediaList, err := app.DeleteOrphanedMedia(ctx, time.Now().Add(-mediaGracePeriod), mediaCollectBatchSize)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Println("Error collecting orphaned media:", err)
+			}
+			break
+		}
+
+		for _, media := range mediaList {
+			if err := mc.MediaService.Storage.DeleteFile(ctx, mediaDirectory, media.ID.String()); err != nil {
+				log.Printf("Error deleting media file %s: %v", media.ID, err)
+			}
+		}
+		collected += len(mediaList)
+
+		if len(mediaList) < mediaCollectBatchSize {
+			break
+		}
+	}
+
+	if collected > 0 {
+		log.Printf("MediaCollector: %d orphaned media removed", collected)
+	}
+}
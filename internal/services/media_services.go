@@ -0,0 +1,73 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"context"
+	"github.com/google/uuid"
+	"log"
+	"regexp"
+)
+
+const mediaDirectory = "media"
+
+// mediaReference finds the media referenced by a content through their URL, in Markdown as well as in HTML
+var mediaReference = regexp.MustCompile(`/` + mediaDirectory + `/([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\.jpg`)
+
+type MediaService struct {
+	Storage *S3Service
+}
+
+func NewMediaService(storage *S3Service) *MediaService {
+	return &MediaService{Storage: storage}
+}
+
+// UploadMedia stores a JPEG image to embed in articles. The URL returned never changes, the media is
+// kept as long as an article or a revision references it and removed by MediaCollector once none does.
+func (ms *MediaService) UploadMedia(ctx context.Context, userID uuid.UUID, image []byte) (*models.Media, error) {
+	media := &models.Media{
+		ID:          uuid.New(),
+		UserID:      userID,
+		ContentType: "image/jpeg",
+		Size:        len(image),
+	}
+
+	if err := ms.Storage.UploadFileToR2(ctx, mediaDirectory, media.ID.String(), image); err != nil {
+		return nil, err
+	}
+	media.URL = ms.Storage.GetDocumentR2(mediaDirectory, media.ID.String()+".jpg")
+
+	if err := app.CreateMedia(media); err != nil {
+		if err := ms.Storage.DeleteFile(ctx, mediaDirectory, media.ID.String()); err != nil {
+			log.Println("Error deleting uploaded media:", err)
+		}
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// referencedMedia returns the ids of the media a content references, each once
+func referencedMedia(content string) []uuid.UUID {
+	ids := []uuid.UUID{}
+	seen := map[uuid.UUID]bool{}
+	for _, match := range mediaReference.FindAllStringSubmatch(content, -1) {
+		id, err := uuid.Parse(match[1])
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// linkNewsMedia links a news to the media of its content, media it no longer references become orphans
+func linkNewsMedia(newsID int, content string) error {
+	return app.SetNewsMedia(newsID, referencedMedia(content))
+}
+
+// linkEventMedia links an event to the media of its description, media it no longer references become orphans
+func linkEventMedia(eventID int, description string) error {
+	return app.SetEventMedia(eventID, referencedMedia(description))
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxNewsCommentLength  = 2000
+	maxReportReasonLength = 500
+	// newsCommentRateLimit comments may be written by a user within newsCommentRateWindow
+	newsCommentRateLimit  = 5
+	newsCommentRateWindow = time.Minute
+)
+
+// validateNewsCommentContent trims a comment and checks its length
+func validateNewsCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return "", utils.BadRequestError{Message: "Comment cannot be empty"}
+	}
+	if utf8.RuneCountInString(content) > maxNewsCommentLength {
+		return "", utils.BadRequestError{Message: "Comment cannot be longer than 2000 characters"}
+	}
+	return content, nil
+}
+
+// requirePublicNews returns a NotFoundError unless the news is published
+func requirePublicNews(newsID int) error {
+	news, err := app.GetNewsByID(newsID)
+	if err != nil || !isPublicNews(news, time.Now()) {
+		return &utils.NotFoundError{Message: "News not found"}
+	}
+	return nil
+}
+
+// ListNewsComments retrieves the comments of a published news as threads. Hidden and deleted comments
+// are left out, or emptied when they still have replies to show.
+func (ns *NewsService) ListNewsComments(newsID int) ([]*models.NewsComment, error) {
+	if err := requirePublicNews(newsID); err != nil {
+		return nil, err
+	}
+
+	comments, err := app.ListNewsComments(newsID)
+	if err != nil {
+		return nil, err
+	}
+
+	threads := []*models.NewsComment{}
+	byID := make(map[int]*models.NewsComment)
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			byID[comment.ID] = comment
+			threads = append(threads, comment)
+			continue
+		}
+
+		parent := byID[*comment.ParentID]
+		if parent == nil || comment.Hidden || comment.Deleted {
+			continue
+		}
+		parent.Replies = append(parent.Replies, comment)
+	}
+
+	visible := threads[:0]
+	for _, thread := range threads {
+		if thread.Hidden || thread.Deleted {
+			if len(thread.Replies) == 0 {
+				continue
+			}
+			thread.Content = ""
+		}
+		visible = append(visible, thread)
+	}
+	return visible, nil
+}
+
+// CreateNewsComment adds a comment to a published news, or a reply when ParentID is set.
+// Users may write newsCommentRateLimit comments per newsCommentRateWindow.
+func (ns *NewsService) CreateNewsComment(newsID int, userID uuid.UUID, input *models.NewsCommentInput) (*models.NewsComment, error) {
+	content, err := validateNewsCommentContent(input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := requirePublicNews(newsID); err != nil {
+		return nil, err
+	}
+
+	if input.ParentID != nil {
+		parent, err := app.GetNewsComment(*input.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.NewsID != newsID {
+			return nil, utils.BadRequestError{Message: "The comment replied to belongs to another news"}
+		}
+		if parent.ParentID != nil {
+			return nil, utils.BadRequestError{Message: "Replies cannot be replied to"}
+		}
+		if parent.Hidden || parent.Deleted {
+			return nil, utils.BadRequestError{Message: "The comment replied to is no longer available"}
+		}
+	}
+
+	recent, err := app.CountNewsCommentsSince(userID, time.Now().Add(-newsCommentRateWindow))
+	if err != nil {
+		return nil, err
+	}
+	if recent >= newsCommentRateLimit {
+		return nil, &utils.TooManyRequestsError{Message: "You are commenting too fast, please wait a moment"}
+	}
+
+	comment := &models.NewsComment{NewsID: newsID, UserID: userID, ParentID: input.ParentID, Content: content}
+	if err := app.CreateNewsComment(comment); err != nil {
+		return nil, err
+	}
+
+	return app.GetNewsComment(comment.ID)
+}
+
+// EditNewsComment replaces the content of a comment, only its author may edit it
+func (ns *NewsService) EditNewsComment(commentID int, userID uuid.UUID, input *models.NewsCommentInput) (*models.NewsComment, error) {
+	content, err := validateNewsCommentContent(input.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := app.GetNewsComment(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, utils.UnauthorizedError{Message: "Only the author of a comment can edit it"}
+	}
+	if comment.Hidden || comment.Deleted {
+		return nil, utils.BadRequestError{Message: "Hidden or deleted comments cannot be edited"}
+	}
+
+	if err := app.UpdateNewsCommentContent(commentID, content); err != nil {
+		return nil, err
+	}
+
+	return app.GetNewsComment(commentID)
+}
+
+// DeleteNewsComment deletes a comment, which its author and moderators may do
+func (ns *NewsService) DeleteNewsComment(commentID int, userID uuid.UUID, moderator bool) error {
+	comment, err := app.GetNewsComment(commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID != userID && !moderator {
+		return utils.UnauthorizedError{Message: "Only the author of a comment or a moderator can delete it"}
+	}
+
+	return app.DeleteNewsComment(commentID, userID)
+}
+
+// SetNewsCommentHidden hides a comment from readers or shows it again, hiding resolves its reports
+func (ns *NewsService) SetNewsCommentHidden(commentID int, hidden bool, moderatorID uuid.UUID) (*models.NewsComment, error) {
+	if err := app.SetNewsCommentHidden(commentID, hidden, moderatorID); err != nil {
+		return nil, err
+	}
+
+	return app.GetNewsComment(commentID)
+}
+
+// ReportNewsComment reports a comment to the moderators, reporting it again changes nothing
+func (ns *NewsService) ReportNewsComment(commentID int, userID uuid.UUID, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return utils.BadRequestError{Message: "Reason is required"}
+	}
+	if utf8.RuneCountInString(reason) > maxReportReasonLength {
+		return utils.BadRequestError{Message: "Reason cannot be longer than 500 characters"}
+	}
+
+	comment, err := app.GetNewsComment(commentID)
+	if err != nil {
+		return err
+	}
+	if comment.Hidden || comment.Deleted {
+		return &utils.NotFoundError{Message: "Comment not found"}
+	}
+	if comment.UserID == userID {
+		return utils.BadRequestError{Message: "You cannot report your own comment"}
+	}
+
+	return app.ReportNewsComment(commentID, userID, reason)
+}
+
+// ListReportedNewsComments retrieves the moderation queue
+func (ns *NewsService) ListReportedNewsComments() ([]*models.ReportedNewsComment, error) {
+	return app.ListReportedNewsComments()
+}
+
+// DismissNewsCommentReports closes the open reports of a comment, keeping it visible
+func (ns *NewsService) DismissNewsCommentReports(commentID int, moderatorID uuid.UUID) error {
+	return app.DismissNewsCommentReports(commentID, moderatorID)
+}
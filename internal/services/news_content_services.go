@@ -0,0 +1,71 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"errors"
+	"log"
+)
+
+const (
+	newsExcerptLength      = 200
+	newsWordsPerMinute     = 200
+	newsRenderingBatchSize = 100
+)
+
+// renderNewsContent renders the content of a news to sanitized HTML and derives its text, excerpt and reading time.
+// Content is authored in Markdown unless content_format says html, which is sanitized as well.
+func renderNewsContent(news *models.News) error {
+	switch news.ContentFormat {
+	case "", models.NewsContentMarkdown:
+		news.ContentFormat = models.NewsContentMarkdown
+		news.ContentHTML = utils.SanitizeHTML(utils.RenderMarkdown(news.Content))
+	case models.NewsContentHTML:
+		news.ContentHTML = utils.SanitizeHTML(news.Content)
+	default:
+		return utils.BadRequestError{Message: "Content format must be markdown or html"}
+	}
+
+	news.ContentText = utils.HTMLToText(news.ContentHTML)
+	news.Excerpt = utils.Excerpt(news.ContentText, newsExcerptLength)
+	news.ReadingTime = utils.ReadingTime(news.ContentText, newsWordsPerMinute)
+	return nil
+}
+
+// RenderStoredContent renders the news saved before content was rendered on save, batch by batch until none is left
+func (ns *NewsService) RenderStoredContent(ctx context.Context) {
+	rendered := 0
+	for {
+		newsList, err := app.ListNewsWithoutHTML(ctx, newsRenderingBatchSize)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Println("Error listing news to render:", err)
+			}
+			return
+		}
+
+		for _, news := range newsList {
+			if err := renderNewsContent(news); err != nil {
+				log.Printf("Error rendering news %d: %v", news.ID, err)
+				return
+			}
+			if err := app.SetNewsRenderedContent(ctx, news); err != nil {
+				if !errors.Is(err, context.Canceled) {
+					log.Printf("Error storing rendered news %d: %v", news.ID, err)
+				}
+				return
+			}
+			rendered++
+		}
+
+		if len(newsList) < newsRenderingBatchSize {
+			break
+		}
+	}
+
+	if rendered > 0 {
+		log.Printf("NewsService: %d news rendered", rendered)
+	}
+}
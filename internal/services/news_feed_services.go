@@ -0,0 +1,86 @@
+package services
+
+import (
+	"Backend/configs"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+)
+
+// newsFeedURL is where a news feed is served from in the given format, rss or atom. It is built from the
+// configured base URL rather than the request so the feed keeps one identity behind any host name.
+func newsFeedURL(baseURL string, organizationID int, format string) string {
+	path := "/api/v1/news/feeds/"
+	if organizationID != 0 {
+		path += "organization/" + strconv.Itoa(organizationID) + "/"
+	}
+	return baseURL + path + format
+}
+
+// NewsFeed builds the syndication feed of the latest published news, of one organization when organizationID is set,
+// for the given format, rss or atom. The feed is updated when its latest news was published or edited.
+func (ns *NewsService) NewsFeed(organizationID int, format string) (*utils.Feed, error) {
+	queryParams := map[string]string{}
+	if organizationID != 0 {
+		queryParams["organization_id"] = strconv.Itoa(organizationID)
+	}
+
+	newsList, _, err := ns.ListNews(queryParams, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := configs.LoadConfig().BaseURL
+	feed := &utils.Feed{
+		Title:       "PUFA Computer Science News",
+		Description: "The latest news of PUFA Computer Science",
+		Link:        baseURL + "/news",
+		SelfURL:     newsFeedURL(baseURL, organizationID, format),
+		Items:       make([]utils.FeedItem, 0, len(newsList)),
+	}
+	if organizationID != 0 && len(newsList) > 0 {
+		feed.Title = newsList[0].Organization + " News"
+		feed.Description = "The latest news of " + newsList[0].Organization
+	}
+
+	for _, news := range newsList {
+		item := toFeedItem(news, baseURL)
+		if item.Updated.After(feed.Updated) {
+			feed.Updated = item.Updated
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
+
+// toFeedItem converts a news for a feed, its thumbnail becomes the enclosure
+func toFeedItem(news *models.News, baseURL string) utils.FeedItem {
+	item := utils.FeedItem{
+		ID:          fmt.Sprintf("tag:%s,2024:news-%d", calendarUIDDomain, news.ID),
+		Title:       news.Title,
+		Link:        baseURL + "/news/" + news.Slug,
+		Summary:     news.Excerpt,
+		ContentHTML: news.ContentHTML,
+		Author:      strings.TrimSpace(news.Author),
+		Published:   news.PublishDate,
+		Updated:     news.UpdatedAt,
+	}
+	// A news edited before being published was last updated when it went live
+	if item.Updated.Before(item.Published) {
+		item.Updated = item.Published
+	}
+
+	for _, tag := range news.Tags {
+		item.Categories = append(item.Categories, tag.Name)
+	}
+
+	if news.Thumbnail != "" {
+		item.EnclosureURL = news.Thumbnail
+		item.EnclosureType = "image/jpeg"
+	}
+	return item
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+// attachLikedByMe tells for each news whether the viewer likes it, visitors (uuid.Nil) like nothing
+func attachLikedByMe(viewerID uuid.UUID, news ...*models.News) error {
+	if viewerID == uuid.Nil || len(news) == 0 {
+		return nil
+	}
+
+	newsIDs := make([]int, len(news))
+	for i, n := range news {
+		newsIDs[i] = n.ID
+	}
+
+	liked, err := app.ListLikedNewsIDs(viewerID, newsIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range news {
+		n.LikedByMe = liked[n.ID]
+	}
+	return nil
+}
+
+// LikeNews likes a published news and returns its number of likes, liking it twice is harmless
+func (ns *NewsService) LikeNews(userID uuid.UUID, newsID int) (int, error) {
+	news, err := app.GetNewsByID(newsID)
+	if err != nil || !isPublicNews(news, time.Now()) {
+		return 0, &utils.NotFoundError{Message: "News not found"}
+	}
+
+	return app.LikeNews(userID, newsID)
+}
+
+// UnlikeNews removes a like and returns the number of likes left, unliking a news not liked is harmless
+func (ns *NewsService) UnlikeNews(userID uuid.UUID, newsID int) (int, error) {
+	if _, err := app.GetNewsByID(newsID); err != nil {
+		return 0, &utils.NotFoundError{Message: "News not found"}
+	}
+
+	return app.UnlikeNews(userID, newsID)
+}
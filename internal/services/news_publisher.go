@@ -0,0 +1,79 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+type NewsPublisher struct {
+	NewsService *NewsService
+}
+
+func NewNewsPublisher(newsService *NewsService) *NewsPublisher {
+	return &NewsPublisher{NewsService: newsService}
+}
+
+// Run publishes scheduled news until ctx is cancelled. Like EventStatusUpdater it sleeps until
+// the next publish date, and several replicas may run it safely thanks to an advisory lock.
+func (p *NewsPublisher) Run(ctx context.Context) {
+	log.Println("NewsPublisher: started")
+
+	for {
+		wait := p.runOnce(ctx)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			log.Println("NewsPublisher: stopped")
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// runOnce publishes the news that are due and returns how long to wait before the next run
+func (p *NewsPublisher) runOnce(ctx context.Context) time.Duration {
+	now := time.Now()
+
+	acquired, published, err := app.PublishScheduledNews(ctx, now)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Println("Error publishing scheduled news:", err)
+		}
+		return maxStatusCheckInterval
+	}
+
+	if !acquired {
+		// Another replica is publishing the scheduled news
+		return maxStatusCheckInterval
+	}
+
+	if published > 0 {
+		log.Printf("NewsPublisher: %d scheduled news published", published)
+	}
+
+	next, err := app.NextScheduledNews(ctx, now)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Println("Error finding next scheduled news:", err)
+		}
+		return maxStatusCheckInterval
+	}
+
+	if next == nil {
+		return maxStatusCheckInterval
+	}
+
+	wait := time.Until(*next)
+	if wait < minStatusCheckInterval {
+		return minStatusCheckInterval
+	}
+	if wait > maxStatusCheckInterval {
+		return maxStatusCheckInterval
+	}
+	return wait
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"github.com/google/uuid"
+	"log"
+	"time"
+)
+
+const (
+	newsThumbnailDirectory         = "news"
+	newsRevisionThumbnailDirectory = "news-revisions"
+)
+
+// newNewsRevision returns the revision recording the current title, content and thumbnail of a news after latest,
+// or nil when they are those of latest. A changed thumbnail is copied since the original is replaced in place.
+func (ns *NewsService) newNewsRevision(ctx context.Context, news *models.News, latest *models.NewsRevision, editorID uuid.UUID) *models.NewsRevision {
+	if latest != nil && latest.Title == news.Title && latest.Content == news.Content &&
+		latest.ContentFormat == news.ContentFormat && latest.Thumbnail == news.Thumbnail {
+		return nil
+	}
+
+	revision := &models.NewsRevision{
+		NewsID:        news.ID,
+		Title:         news.Title,
+		Content:       news.Content,
+		ContentFormat: news.ContentFormat,
+		Thumbnail:     news.Thumbnail,
+	}
+	if editorID != uuid.Nil {
+		revision.EditorID = &editorID
+	}
+
+	switch {
+	case latest != nil && latest.Thumbnail == news.Thumbnail:
+		revision.ThumbnailKey = latest.ThumbnailKey
+	case news.Thumbnail != "" && ns.Storage != nil:
+		key := uuid.NewString()
+		if err := ns.Storage.CopyFileR2(ctx, newsThumbnailDirectory, news.Slug, newsRevisionThumbnailDirectory, key); err != nil {
+			// Thumbnails of older news are not in R2, the revision keeps their URL only
+			log.Printf("Thumbnail of news %d could not be copied for its revision: %v", news.ID, err)
+		} else {
+			revision.ThumbnailKey = &key
+		}
+	}
+
+	return revision
+}
+
+// discardNewsRevision removes the thumbnail copy made for a revision that was not saved
+func (ns *NewsService) discardNewsRevision(ctx context.Context, revision, latest *models.NewsRevision) {
+	if revision == nil || revision.ThumbnailKey == nil || ns.Storage == nil {
+		return
+	}
+	if latest != nil && latest.ThumbnailKey != nil && *latest.ThumbnailKey == *revision.ThumbnailKey {
+		return
+	}
+
+	if err := ns.Storage.DeleteFile(ctx, newsRevisionThumbnailDirectory, *revision.ThumbnailKey); err != nil {
+		log.Printf("Error deleting revision thumbnail %s: %v", *revision.ThumbnailKey, err)
+	}
+}
+
+// latestRevisionNumber returns the number of the latest revision of a news, 0 when it has none
+func latestRevisionNumber(latest *models.NewsRevision) int {
+	if latest == nil {
+		return 0
+	}
+	return latest.Revision
+}
+
+// ListNewsRevisions returns the revisions of a news without their content, latest first
+func (ns *NewsService) ListNewsRevisions(newsID int) ([]*models.NewsRevision, error) {
+	if _, err := app.GetNewsByID(newsID); err != nil {
+		return nil, &utils.NotFoundError{Message: "News not found"}
+	}
+
+	return app.ListNewsRevisions(newsID)
+}
+
+// GetNewsRevision retrieves a revision of a news with its content
+func (ns *NewsService) GetNewsRevision(newsID, revision int) (*models.NewsRevision, error) {
+	return app.GetNewsRevision(newsID, revision)
+}
+
+// DiffNewsRevisions compares the revision from of a news with the revision to, line by line
+func (ns *NewsService) DiffNewsRevisions(newsID, from, to int) (*models.NewsRevisionDiff, error) {
+	before, err := app.GetNewsRevision(newsID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := app.GetNewsRevision(newsID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &models.NewsRevisionDiff{
+		TitleChanged:     before.Title != after.Title,
+		ThumbnailChanged: before.Thumbnail != after.Thumbnail,
+		FormatChanged:    before.ContentFormat != after.ContentFormat,
+		Content:          utils.DiffLines(before.Content, after.Content),
+	}
+	for _, line := range diff.Content {
+		switch line.Operation {
+		case utils.DiffInsert:
+			diff.LinesAdded++
+		case utils.DiffDelete:
+			diff.LinesRemoved++
+		}
+	}
+
+	// The content of both revisions is already in the diff
+	before.Content, after.Content = "", ""
+	diff.From, diff.To = before, after
+	return diff, nil
+}
+
+// RestoreNewsRevision brings the title, content and thumbnail of a news back to those of an earlier revision,
+// recorded as a new revision. Images embedded in the content are kept as long as a revision references them.
+func (ns *NewsService) RestoreNewsRevision(ctx context.Context, newsID, revision int, editorID uuid.UUID) (*models.News, error) {
+	news, err := app.GetNewsByID(newsID)
+	if err != nil {
+		return nil, &utils.NotFoundError{Message: "News not found"}
+	}
+
+	restored, err := app.GetNewsRevision(newsID, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	if restored.Title != news.Title {
+		if news.Slug, err = uniqueNewsSlug(restored.Title, newsID); err != nil {
+			return nil, err
+		}
+	}
+	news.Title = restored.Title
+	news.Content = restored.Content
+	news.ContentFormat = restored.ContentFormat
+	news.Thumbnail = restored.Thumbnail
+
+	latest, err := app.GetLatestNewsRevision(newsID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The restored thumbnail replaces the current one in place, it is put back if the restore is not saved
+	thumbnailRestored := false
+	if restored.ThumbnailKey != nil && ns.Storage != nil {
+		err := ns.Storage.CopyFileR2(ctx, newsRevisionThumbnailDirectory, *restored.ThumbnailKey, newsThumbnailDirectory, news.Slug)
+		if err != nil {
+			return nil, err
+		}
+		news.Thumbnail, _ = ns.Storage.GetFileR2(newsThumbnailDirectory, news.Slug)
+		thumbnailRestored = true
+	}
+
+	news.UpdatedAt = time.Now()
+	if err := renderNewsContent(news); err != nil {
+		return nil, err
+	}
+
+	// A restore is always recorded, with the thumbnail copy of the revision it restores
+	revisionNumber := restored.Revision
+	record := &models.NewsRevision{
+		NewsID:        news.ID,
+		Title:         news.Title,
+		Content:       news.Content,
+		ContentFormat: news.ContentFormat,
+		Thumbnail:     news.Thumbnail,
+		ThumbnailKey:  restored.ThumbnailKey,
+		RestoredFrom:  &revisionNumber,
+	}
+	if editorID != uuid.Nil {
+		record.EditorID = &editorID
+	}
+
+	if err := app.SaveNewsEdit(news, referencedMedia(news.Content), record, latestRevisionNumber(latest)); err != nil {
+		if thumbnailRestored && latest != nil && latest.ThumbnailKey != nil {
+			if err := ns.Storage.CopyFileR2(ctx, newsRevisionThumbnailDirectory, *latest.ThumbnailKey, newsThumbnailDirectory, news.Slug); err != nil {
+				log.Printf("Thumbnail of news %d could not be put back: %v", newsID, err)
+			}
+		}
+		return nil, err
+	}
+
+	news, err = app.GetNewsBySlug(news.Slug)
+	if err != nil {
+		return nil, err
+	}
+	return news, attachNewsTags(news)
+}
+
+// deleteNewsRevisionThumbnails removes the thumbnail copies of the revisions of a deleted news
+func (ns *NewsService) deleteNewsRevisionThumbnails(ctx context.Context, keys []string) {
+	if ns.Storage == nil {
+		return
+	}
+
+	for _, key := range keys {
+		if err := ns.Storage.DeleteFile(ctx, newsRevisionThumbnailDirectory, key); err != nil {
+			log.Printf("Error deleting revision thumbnail %s: %v", key, err)
+		}
+	}
+}
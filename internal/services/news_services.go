@@ -4,44 +4,130 @@ import (
 	"Backend/internal/database/app"
 	"Backend/internal/models"
 	"Backend/pkg/utils"
+	"context"
 	"github.com/google/uuid"
+	"time"
 )
 
 type NewsService struct {
+	Storage     *S3Service
+	ViewCounter *NewsViewCounter
 }
 
-func NewNewsService() *NewsService {
-	return &NewsService{}
+func NewNewsService(storage *S3Service) *NewsService {
+	return &NewsService{Storage: storage, ViewCounter: NewNewsViewCounter()}
 }
 
+// CreateNews stores a news, news created without a status are published on their publish date
 func (ns *NewsService) CreateNews(news *models.News) error {
+	if news.Status == "" {
+		news.Status = models.NewsStatusPublished
+	}
+	if err := applyNewsStatus(news, time.Now()); err != nil {
+		return err
+	}
+
+	if err := renderNewsContent(news); err != nil {
+		return err
+	}
+
+	tagIDs, err := uniqueNewsTagIDs(news.TagIDs)
+	if err != nil {
+		return err
+	}
+
 	if err := app.CreateNews(news); err != nil {
 		return err
 	}
 
-	return nil
+	if err := linkNewsMedia(news.ID, news.Content); err != nil {
+		return err
+	}
+
+	// The first revision of a news is its state when it was created
+	revision := ns.newNewsRevision(context.Background(), news, nil, news.UserID)
+	if err := app.CreateNewsRevision(revision, referencedMedia(news.Content)); err != nil {
+		return err
+	}
+
+	if len(tagIDs) > 0 {
+		if err := app.SetNewsTags(news.ID, tagIDs); err != nil {
+			return err
+		}
+	}
+	news.TagIDs = nil
+	return attachNewsTags(news)
 }
 
-func (ns *NewsService) EditNews(newsID int, updatedNews *models.News) error {
+// EditNews updates the fields set in updatedNews and fills it with the resulting news,
+// changes to the title, content or thumbnail are recorded as a revision by editorID
+func (ns *NewsService) EditNews(newsID int, editorID uuid.UUID, updatedNews *models.News) error {
 	existingNews, err := app.GetNewsByID(newsID)
 	if err != nil {
 		return err
 	}
 
+	if updatedNews.Status != "" && updatedNews.Status != existingNews.Status && !existingNews.Status.CanTransitionTo(updatedNews.Status) {
+		return utils.BadRequestError{Message: "A " + string(existingNews.Status) + " news cannot be moved to " + string(updatedNews.Status)}
+	}
+
 	utils.ReflectiveUpdate(existingNews, updatedNews)
+	existingNews.UpdatedAt = time.Now()
 
-	if err := app.UpdateNews(newsID, updatedNews); err != nil {
+	if err := applyNewsStatus(existingNews, existingNews.UpdatedAt); err != nil {
 		return err
 	}
 
-	return nil
+	// The rendering always follows the merged source, rendered fields sent by clients are ignored
+	if err := renderNewsContent(existingNews); err != nil {
+		return err
+	}
+
+	// Tags are only replaced when tag_ids is given
+	var tagIDs []int
+	if updatedNews.TagIDs != nil {
+		if tagIDs, err = uniqueNewsTagIDs(updatedNews.TagIDs); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	latest, err := app.GetLatestNewsRevision(newsID)
+	if err != nil {
+		return err
+	}
+
+	// The news, its media links and its revision are saved together, or not at all when another edit came first
+	revision := ns.newNewsRevision(ctx, existingNews, latest, editorID)
+	if err := app.SaveNewsEdit(existingNews, referencedMedia(existingNews.Content), revision, latestRevisionNumber(latest)); err != nil {
+		ns.discardNewsRevision(ctx, revision, latest)
+		return err
+	}
+
+	if tagIDs != nil {
+		if err := app.SetNewsTags(newsID, tagIDs); err != nil {
+			return err
+		}
+	}
+
+	existingNews.TagIDs = nil
+	*updatedNews = *existingNews
+	return attachNewsTags(updatedNews)
 }
 
 func (ns *NewsService) DeleteNews(newsID int) error {
+	// Revisions go with the news, their thumbnail copies are removed once it is deleted
+	thumbnailKeys, err := app.ListNewsRevisionThumbnailKeys(newsID)
+	if err != nil {
+		return err
+	}
+
 	if err := app.DeleteNews(newsID); err != nil {
 		return err
 	}
 
+	ns.deleteNewsRevisionThumbnails(context.Background(), thumbnailKeys)
+
 	return nil
 }
 
@@ -53,25 +139,41 @@ func (ns *NewsService) GetNewsByID(newsID int) (*models.News, error) {
 	return news, nil
 }
 
-func (ns *NewsService) GetNewsBySlug(slug string) (*models.News, error) {
-	news, err := app.GetNewsBySlug(slug)
+// GetNewsBySlug retrieves a published news and counts a view of it by the viewer, drafts and news scheduled for later are not found
+func (ns *NewsService) GetNewsBySlug(slug string, viewerID uuid.UUID, clientIP string) (*models.News, error) {
+	news, err := findNewsBySlug(slug)
 	if err != nil {
 		return nil, err
 	}
+
+	if !isPublicNews(news, time.Now()) {
+		return nil, &utils.NotFoundError{Message: "News not found"}
+	}
+
+	go ns.ViewCounter.Record(news.ID, newsViewer(viewerID, clientIP))
+
+	if err := attachLikedByMe(viewerID, news); err != nil {
+		return nil, err
+	}
+	if err := attachNewsTags(news); err != nil {
+		return nil, err
+	}
 	return news, nil
 }
 
-func (ns *NewsService) ListNews(queryParams map[string]string) ([]*models.News, int, error) {
+// ListNews lists the published news, viewerID tells which of them are liked and is uuid.Nil for visitors
+func (ns *NewsService) ListNews(queryParams map[string]string, viewerID uuid.UUID) ([]*models.News, int, error) {
 	news, totalRecords, err := app.ListNews(queryParams)
 	if err != nil {
 		return nil, 0, err
 	}
-	return news, totalRecords, nil
-}
 
-func (ns *NewsService) LikeNews(userID uuid.UUID, newsID int) error {
-	if err := app.LikeNews(userID, newsID); err != nil {
-		return err
+	if err := attachLikedByMe(viewerID, news...); err != nil {
+		return nil, 0, err
 	}
-	return nil
+	if err := attachNewsTags(news...); err != nil {
+		return nil, 0, err
+	}
+	return news, totalRecords, nil
 }
+
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"time"
+)
+
+// applyNewsStatus validates the status of a news and, for scheduled and published news,
+// derives it from the publish date so a news dated in the future waits for it
+func applyNewsStatus(news *models.News, now time.Time) error {
+	if !news.Status.IsValid() {
+		return utils.BadRequestError{Message: "Status must be draft, scheduled, published or archived"}
+	}
+
+	if news.PublishDate.IsZero() {
+		news.PublishDate = now
+	}
+
+	if news.Status.IsDateDriven() {
+		news.Status = models.NewsStatusForDate(news.PublishDate, now)
+	}
+	return nil
+}
+
+// isPublicNews tells whether a news is shown to everyone
+func isPublicNews(news *models.News, now time.Time) bool {
+	return news.Status == models.NewsStatusPublished && !news.PublishDate.After(now)
+}
+
+// UpdateNewsStatus publishes, schedules, unpublishes or archives a news. Publishing a news dated
+// in the future publishes it right away unless a publish date is given.
+func (ns *NewsService) UpdateNewsStatus(newsID int, update *models.NewsStatusUpdate) (*models.News, error) {
+	if !update.Status.IsValid() {
+		return nil, utils.BadRequestError{Message: "Status must be draft, scheduled, published or archived"}
+	}
+
+	news, err := app.GetNewsByID(newsID)
+	if err != nil {
+		return nil, &utils.NotFoundError{Message: "News not found"}
+	}
+
+	if !news.Status.CanTransitionTo(update.Status) {
+		return nil, utils.BadRequestError{Message: "A " + string(news.Status) + " news cannot be moved to " + string(update.Status)}
+	}
+
+	now := time.Now()
+	switch {
+	case update.PublishDate != nil:
+		news.PublishDate = *update.PublishDate
+	case update.Status == models.NewsStatusPublished && news.PublishDate.After(now):
+		news.PublishDate = now
+	}
+
+	if update.Status == models.NewsStatusScheduled && !news.PublishDate.After(now) {
+		return nil, utils.BadRequestError{Message: "A scheduled news needs a publish date in the future"}
+	}
+
+	news.Status = update.Status
+	if err := applyNewsStatus(news, now); err != nil {
+		return nil, err
+	}
+
+	if err := app.UpdateNewsStatus(newsID, news.Status, news.PublishDate); err != nil {
+		return nil, err
+	}
+	return news, nil
+}
+
+// PreviewNews retrieves a news whatever its status, so authors can review drafts and scheduled news
+func (ns *NewsService) PreviewNews(newsID int) (*models.News, error) {
+	news, err := app.GetNewsByID(newsID)
+	if err != nil {
+		return nil, &utils.NotFoundError{Message: "News not found"}
+	}
+
+	news, err = app.GetNewsBySlug(news.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachNewsTags(news); err != nil {
+		return nil, err
+	}
+	return news, nil
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxNewsTagNameLength    = 100
+	defaultNewsTagCloudSize = 30
+	maxNewsTagCloudSize     = 100
+)
+
+// attachNewsTags fills in the tags of news with a single query
+func attachNewsTags(news ...*models.News) error {
+	if len(news) == 0 {
+		return nil
+	}
+
+	newsIDs := make([]int, len(news))
+	for i, n := range news {
+		newsIDs[i] = n.ID
+	}
+
+	tags, err := app.ListTagsOfNews(newsIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range news {
+		n.Tags = tags[n.ID]
+		if n.Tags == nil {
+			n.Tags = []models.NewsTag{}
+		}
+	}
+	return nil
+}
+
+// validateNewsTagKind checks a kind given as a filter, an empty kind matches tags and categories
+func validateNewsTagKind(kind string) error {
+	if kind != "" && kind != models.NewsTagKindTag && kind != models.NewsTagKindCategory {
+		return utils.BadRequestError{Message: "Kind must be tag or category"}
+	}
+	return nil
+}
+
+// prepareNewsTag validates a tag and derives its slug from its name
+func prepareNewsTag(tag *models.NewsTag) error {
+	tag.Name = strings.TrimSpace(tag.Name)
+	tag.Description = strings.TrimSpace(tag.Description)
+	if tag.Name == "" {
+		return utils.BadRequestError{Message: "Name is required"}
+	}
+	if utf8.RuneCountInString(tag.Name) > maxNewsTagNameLength {
+		return utils.BadRequestError{Message: "Name cannot be longer than 100 characters"}
+	}
+
+	if tag.Kind == "" {
+		tag.Kind = models.NewsTagKindTag
+	}
+	if err := validateNewsTagKind(tag.Kind); err != nil {
+		return err
+	}
+
+	tag.Slug = utils.GenerateFriendlyURL(tag.Name)
+	if tag.Slug == "" {
+		return utils.BadRequestError{Message: "Name must contain a letter or a digit"}
+	}
+	return nil
+}
+
+// uniqueNewsTagIDs drops repeated tag IDs and checks that every tag exists
+func uniqueNewsTagIDs(tagIDs []int) ([]int, error) {
+	seen := make(map[int]bool)
+	unique := []int{}
+	for _, tagID := range tagIDs {
+		if !seen[tagID] {
+			seen[tagID] = true
+			unique = append(unique, tagID)
+		}
+	}
+
+	if len(unique) == 0 {
+		return unique, nil
+	}
+
+	exist, err := app.NewsTagsExist(unique)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, utils.BadRequestError{Message: "Unknown tag"}
+	}
+	return unique, nil
+}
+
+// CreateNewsTag creates a tag or a category
+func (ns *NewsService) CreateNewsTag(tag *models.NewsTag) error {
+	if err := prepareNewsTag(tag); err != nil {
+		return err
+	}
+	return app.CreateNewsTag(tag)
+}
+
+// UpdateNewsTag renames a tag or changes its kind or description
+func (ns *NewsService) UpdateNewsTag(tagID int, tag *models.NewsTag) error {
+	tag.ID = tagID
+	if err := prepareNewsTag(tag); err != nil {
+		return err
+	}
+	return app.UpdateNewsTag(tag)
+}
+
+// DeleteNewsTag deletes a tag and removes it from its news
+func (ns *NewsService) DeleteNewsTag(tagID int) error {
+	return app.DeleteNewsTag(tagID)
+}
+
+// ListNewsTags retrieves the tags, the categories or both when kind is empty
+func (ns *NewsService) ListNewsTags(kind string) ([]*models.NewsTag, error) {
+	if err := validateNewsTagKind(kind); err != nil {
+		return nil, err
+	}
+	return app.ListNewsTags(kind)
+}
+
+// NewsTagCloud retrieves the most used tags with the number of published news filed under them
+func (ns *NewsService) NewsTagCloud(kind, limit string) ([]*models.NewsTag, error) {
+	if err := validateNewsTagKind(kind); err != nil {
+		return nil, err
+	}
+
+	size := defaultNewsTagCloudSize
+	if limit != "" {
+		var err error
+		size, err = strconv.Atoi(limit)
+		if err != nil || size < 1 || size > maxNewsTagCloudSize {
+			return nil, utils.BadRequestError{Message: "Limit must be a number between 1 and 100"}
+		}
+	}
+
+	return app.NewsTagCloud(kind, size)
+}
+
+// SetNewsTags replaces the tags of a news and returns them
+func (ns *NewsService) SetNewsTags(newsID int, tagIDs []int) ([]models.NewsTag, error) {
+	if _, err := app.GetNewsByID(newsID); err != nil {
+		return nil, &utils.NotFoundError{Message: "News not found"}
+	}
+
+	tagIDs, err := uniqueNewsTagIDs(tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := app.SetNewsTags(newsID, tagIDs); err != nil {
+		return nil, err
+	}
+
+	news := &models.News{ID: newsID}
+	if err := attachNewsTags(news); err != nil {
+		return nil, err
+	}
+	return news.Tags, nil
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// newsViewWindow is how long repeated views of a news by the same viewer count as one
+	newsViewWindow        = 30 * time.Minute
+	newsViewFlushInterval = time.Minute
+	newsViewsPendingKey   = "news:views:pending"
+
+	trendingNewsDays       = 7
+	trendingNewsLikeWeight = 5
+	trendingNewsHalfLife   = 48 * time.Hour
+	maxTrendingNews        = 50
+)
+
+// NewsViewCounter counts the views of news. Views are deduplicated per viewer and buffered in Redis, shared by
+// every replica, and added to Postgres every newsViewFlushInterval. Without Redis they are buffered in memory.
+type NewsViewCounter struct {
+	mu      sync.Mutex
+	pending map[int]int64
+	seen    map[string]time.Time
+	// stranded are Redis keys holding views taken for a flush that could be neither saved nor put back
+	stranded []string
+}
+
+func NewNewsViewCounter() *NewsViewCounter {
+	return &NewsViewCounter{pending: map[int]int64{}, seen: map[string]time.Time{}}
+}
+
+// newsViewer identifies a viewer by their user ID, or by a hash of their IP address when they are not logged in
+func newsViewer(viewerID uuid.UUID, clientIP string) string {
+	if viewerID != uuid.Nil {
+		return "user:" + viewerID.String()
+	}
+	sum := sha256.Sum256([]byte(clientIP))
+	return "ip:" + hex.EncodeToString(sum[:16])
+}
+
+// Record counts a view of a news unless the viewer already viewed it within newsViewWindow
+func (vc *NewsViewCounter) Record(newsID int, viewer string) {
+	if utils.RedisEnabled && utils.Rdb != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		first, err := utils.Rdb.SetNX(ctx, fmt.Sprintf("news:viewed:%d:%s", newsID, viewer), 1, newsViewWindow).Result()
+		if err == nil && first {
+			err = utils.Rdb.HIncrBy(ctx, newsViewsPendingKey, strconv.Itoa(newsID), 1).Err()
+		}
+		if err != nil {
+			log.Printf("Error recording a view of news %d: %v", newsID, err)
+		}
+		return
+	}
+
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+
+	key := strconv.Itoa(newsID) + ":" + viewer
+	now := time.Now()
+	if until, ok := vc.seen[key]; ok && now.Before(until) {
+		return
+	}
+	vc.seen[key] = now.Add(newsViewWindow)
+	vc.pending[newsID]++
+}
+
+// Run adds the buffered views to Postgres every newsViewFlushInterval until ctx is cancelled, then one last time
+func (vc *NewsViewCounter) Run(ctx context.Context) {
+	log.Println("NewsViewCounter: started")
+
+	ticker := time.NewTicker(newsViewFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			vc.flush(flushCtx)
+			cancel()
+			log.Println("NewsViewCounter: stopped")
+			return
+		case <-ticker.C:
+			vc.flush(ctx)
+		}
+	}
+}
+
+func (vc *NewsViewCounter) flush(ctx context.Context) {
+	if utils.RedisEnabled && utils.Rdb != nil {
+		vc.flushRedis(ctx)
+	}
+	vc.flushMemory(ctx)
+}
+
+// flushRedis moves the pending views to a key of its own before adding them, so views recorded meanwhile
+// wait for the next flush and two replicas never add the same views. Views that cannot be added are put back,
+// or kept under their key and put back by a later flush when Redis cannot be reached.
+func (vc *NewsViewCounter) flushRedis(ctx context.Context) {
+	vc.retryStrandedViews(ctx)
+
+	flushingKey := newsViewsPendingKey + ":" + uuid.NewString()
+	if err := utils.Rdb.Rename(ctx, newsViewsPendingKey, flushingKey).Err(); err != nil {
+		if !strings.Contains(err.Error(), "no such key") {
+			log.Println("Error flushing news views:", err)
+		}
+		return
+	}
+
+	fields, err := utils.Rdb.HGetAll(ctx, flushingKey).Result()
+	if err != nil {
+		log.Println("Error flushing news views:", err)
+		vc.strand(flushingKey)
+		return
+	}
+
+	views := map[int]int64{}
+	for field, value := range fields {
+		newsID, idErr := strconv.Atoi(field)
+		count, countErr := strconv.ParseInt(value, 10, 64)
+		if idErr == nil && countErr == nil {
+			views[newsID] = count
+		}
+	}
+
+	if err := app.AddNewsViews(ctx, views); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Println("Error saving news views:", err)
+		}
+		// A new context, ctx may be the reason the views could not be saved
+		restoreCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := restoreRedisViews(restoreCtx, flushingKey); err != nil {
+			log.Println("Error putting news views back:", err)
+			vc.strand(flushingKey)
+		}
+		return
+	}
+
+	// The views are saved, a key left behind is never read again but must not be put back either
+	if err := utils.Rdb.Del(context.Background(), flushingKey).Err(); err != nil {
+		log.Printf("Error deleting flushed news views %s: %v", flushingKey, err)
+	}
+}
+
+// restoreRedisViews adds the views of a flushing key back to the pending views and deletes the key,
+// both in one transaction so the views are never put back twice
+func restoreRedisViews(ctx context.Context, flushingKey string) error {
+	fields, err := utils.Rdb.HGetAll(ctx, flushingKey).Result()
+	if err != nil {
+		return err
+	}
+
+	pipe := utils.Rdb.TxPipeline()
+	for field, value := range fields {
+		if count, err := strconv.ParseInt(value, 10, 64); err == nil {
+			pipe.HIncrBy(ctx, newsViewsPendingKey, field, count)
+		}
+	}
+	pipe.Del(ctx, flushingKey)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// strand keeps a flushing key so its views are put back by the next flush
+func (vc *NewsViewCounter) strand(flushingKey string) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.stranded = append(vc.stranded, flushingKey)
+}
+
+// retryStrandedViews puts back the views of flushes that failed earlier, keys that still fail are kept
+func (vc *NewsViewCounter) retryStrandedViews(ctx context.Context) {
+	vc.mu.Lock()
+	stranded := vc.stranded
+	vc.stranded = nil
+	vc.mu.Unlock()
+
+	for _, flushingKey := range stranded {
+		if err := restoreRedisViews(ctx, flushingKey); err != nil {
+			log.Printf("Error putting news views %s back: %v", flushingKey, err)
+			vc.strand(flushingKey)
+		}
+	}
+}
+
+// flushMemory adds the views buffered in memory and forgets viewers whose window is over
+func (vc *NewsViewCounter) flushMemory(ctx context.Context) {
+	vc.mu.Lock()
+	views := vc.pending
+	vc.pending = map[int]int64{}
+	now := time.Now()
+	for key, until := range vc.seen {
+		if now.After(until) {
+			delete(vc.seen, key)
+		}
+	}
+	vc.mu.Unlock()
+
+	if len(views) == 0 {
+		return
+	}
+
+	if err := app.AddNewsViews(ctx, views); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			log.Println("Error saving news views:", err)
+		}
+		vc.mu.Lock()
+		for newsID, count := range views {
+			vc.pending[newsID] += count
+		}
+		vc.mu.Unlock()
+	}
+}
+
+// ListTrendingNews returns up to limit published news ranked by their recent views and likes
+func (ns *NewsService) ListTrendingNews(limit int, viewerID uuid.UUID) ([]*models.News, error) {
+	if limit <= 0 || limit > maxTrendingNews {
+		return nil, utils.BadRequestError{Message: fmt.Sprintf("limit must be between 1 and %d", maxTrendingNews)}
+	}
+
+	news, err := app.ListTrendingNews(trendingNewsDays, limit, trendingNewsLikeWeight, trendingNewsHalfLife)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := attachLikedByMe(viewerID, news...); err != nil {
+		return nil, err
+	}
+	if err := attachNewsTags(news...); err != nil {
+		return nil, err
+	}
+	return news, nil
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -188,3 +189,50 @@ func (s *S3Service) GetFileR2(directory, slug string) (string, error) {
 	fmt.Printf("Generated URL with cache-busting: %s\n", url)
 	return url, nil
 }
+
+// UploadDocumentToR2 stores a file that is not an image, the name includes its extension
+func (s *S3Service) UploadDocumentToR2(ctx context.Context, directory, name, contentType string, file []byte) error {
+	key := directory + "/" + name
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(file),
+		ContentType: aws.String(contentType),
+	}
+
+	_, err := s.s3Client.PutObject(ctx, input)
+	return err
+}
+
+// GetDocumentR2 returns the public URL of a file stored with UploadDocumentToR2
+func (s *S3Service) GetDocumentR2(directory, name string) string {
+	return "https://pufacompsci.my.id/" + directory + "/" + name
+}
+
+// DownloadFileR2 reads a file stored with UploadFileToR2
+func (s *S3Service) DownloadFileR2(ctx context.Context, directory, slug string) ([]byte, error) {
+	key := directory + "/" + slug + ".jpg"
+
+	output, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer output.Body.Close()
+
+	return io.ReadAll(output.Body)
+}
+
+// CopyFileR2 copies a file stored with UploadFileToR2 to another key without downloading it
+func (s *S3Service) CopyFileR2(ctx context.Context, fromDirectory, fromSlug, toDirectory, toSlug string) error {
+	_, err := s.s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:      aws.String(s.bucket),
+		CopySource:  aws.String(s.bucket + "/" + fromDirectory + "/" + fromSlug + ".jpg"),
+		Key:         aws.String(toDirectory + "/" + toSlug + ".jpg"),
+		ContentType: aws.String("image/jpeg"),
+	})
+	return err
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	searchPageSize       = 20
+	maxSearchQueryLength = 200
+
+	// Matches are delimited with control characters by Postgres, they become <mark> once the text is escaped
+	searchStartSel = "\x02"
+	searchStopSel  = "\x03"
+)
+
+var searchTypes = []string{models.SearchTypeNews, models.SearchTypeEvent, models.SearchTypeAspiration}
+
+type SearchService struct {
+}
+
+func NewSearchService() *SearchService {
+	return &SearchService{}
+}
+
+// searchHighlight escapes a highlighted text for HTML and marks its matching words
+func searchHighlight(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, searchStartSel, "<mark>")
+	return strings.ReplaceAll(text, searchStopSel, "</mark>")
+}
+
+// Search looks up news, events and aspirations matching query, types is a comma separated list of
+// the kinds of results wanted and is empty for all of them. Results come by pages of searchPageSize.
+func (s *SearchService) Search(query, types string, page int) ([]*models.SearchResult, int, int, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, 0, 0, utils.BadRequestError{Message: "The search query is required"}
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, 0, 0, utils.BadRequestError{Message: "The search query is too long"}
+	}
+	if page < 1 {
+		return nil, 0, 0, utils.BadRequestError{Message: "Page must be 1 or more"}
+	}
+
+	wanted := searchTypes
+	if types = strings.TrimSpace(types); types != "" {
+		wanted = nil
+		for _, kind := range strings.Split(types, ",") {
+			kind = strings.ToLower(strings.TrimSpace(kind))
+			valid := false
+			for _, searchType := range searchTypes {
+				valid = valid || kind == searchType
+			}
+			if !valid {
+				return nil, 0, 0, utils.BadRequestError{Message: "Type must be news, event or aspiration"}
+			}
+			wanted = append(wanted, kind)
+		}
+	}
+
+	results, total, err := app.Search(query, wanted, searchPageSize, (page-1)*searchPageSize, searchStartSel, searchStopSel)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	for _, result := range results {
+		result.TitleHighlight = searchHighlight(result.TitleHighlight)
+		result.Snippet = searchHighlight(result.Snippet)
+	}
+
+	totalPages := (total + searchPageSize - 1) / searchPageSize
+	return results, total, totalPages, nil
+}
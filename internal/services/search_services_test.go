@@ -0,0 +1,55 @@
+package services
+
+import (
+	"Backend/internal/models"
+	"testing"
+)
+
+func TestSearchHighlight(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain text", "Career fair", "Career fair"},
+		{"match", "The \x02career\x03 fair", "The <mark>career</mark> fair"},
+		{"several matches", "\x02AI\x03 and \x02ai\x03", "<mark>AI</mark> and <mark>ai</mark>"},
+		{"markup is escaped", "<script>alert(1)</script> \x02fair\x03", "&lt;script&gt;alert(1)&lt;/script&gt; <mark>fair</mark>"},
+		{"characters are escaped once", "Tom & Jerry's \x02show\x03", "Tom &amp; Jerry&#39;s <mark>show</mark>"},
+		{"delimiters cannot be forged with markup", "<mark>x</mark>", "&lt;mark&gt;x&lt;/mark&gt;"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchHighlight(tt.text); got != tt.want {
+				t.Errorf("searchHighlight(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderNewsContentText(t *testing.T) {
+	// The text search indexes and highlights carries no Markdown syntax, link targets, tags nor entities
+	tests := []struct {
+		name    string
+		content string
+		format  string
+		want    string
+	}{
+		{"markdown", "Fish & chips at [the fair](https://example.com/fair) **today**", models.NewsContentMarkdown, "Fish & chips at the fair today"},
+		{"html", "<p>Tom &amp; Jerry&#39;s <a href=\"https://example.com\">show</a></p>", models.NewsContentHTML, "Tom & Jerry's show"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			news := &models.News{Content: tt.content, ContentFormat: tt.format}
+			if err := renderNewsContent(news); err != nil {
+				t.Fatal(err)
+			}
+			if news.ContentText != tt.want {
+				t.Errorf("ContentText = %q, want %q", news.ContentText, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"Backend/configs"
+	"Backend/internal/models"
 	"fmt"
 	"log"
 
@@ -51,6 +52,20 @@ func (sg *SendGridService) SendVerificationEmail(to, token string, userId uuid.U
 	return sg.sendEmail(to, subject, body)
 }
 
+// SendEventStatusEmail tells a registrant that an event was cancelled or postponed
+func (sg *SendGridService) SendEventStatusEmail(to, name string, event *models.Event, reason string) error {
+	subject, body := generateEventStatusEmail(name, event, reason)
+
+	return sg.sendEmail(to, subject, body)
+}
+
+// SendEventReminderEmail reminds a registrant of an upcoming event
+func (sg *SendGridService) SendEventReminderEmail(to, name string, event *models.Event) error {
+	subject, body := generateEventReminderEmail(name, event)
+
+	return sg.sendEmail(to, subject, body)
+}
+
 // sendEmail sends an email using SendGrid
 func (sg *SendGridService) sendEmail(toEmail, subject, htmlContent string) error {
 	log.Printf("Attempting to send email to: %s with subject: %s", toEmail, subject)
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"Backend/internal/database/app"
+	"Backend/internal/models"
+	"Backend/pkg/utils"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+)
+
+// uniqueSlug returns base, or base numbered from 2 on when taken reports it is already used.
+// Titles without any letter or digit fall back to the given slug.
+func uniqueSlug(base, fallback string, taken func(slug string) (bool, error)) (string, error) {
+	if base == "" {
+		base = fallback
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		exists, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// uniqueEventSlug returns the slug of a title that no other event than eventID uses or used, 0 for a new event
+func uniqueEventSlug(title string, eventID int) (string, error) {
+	return uniqueSlug(utils.GenerateFriendlyURL(title), "event", func(slug string) (bool, error) {
+		return app.EventSlugTaken(slug, eventID)
+	})
+}
+
+// uniqueNewsSlug returns the slug of a title that no other news than newsID uses or used, 0 for a new news
+func uniqueNewsSlug(title string, newsID int) (string, error) {
+	return uniqueSlug(utils.GenerateFriendlyURL(title), "news", func(slug string) (bool, error) {
+		return app.NewsSlugTaken(slug, newsID)
+	})
+}
+
+// GenerateEventSlug returns a unique slug for the title of an event, eventID is 0 for a new event
+func (es *EventService) GenerateEventSlug(title string, eventID int) (string, error) {
+	return uniqueEventSlug(title, eventID)
+}
+
+// GenerateNewsSlug returns a unique slug for the title of a news, newsID is 0 for a new news
+func (ns *NewsService) GenerateNewsSlug(title string, newsID int) (string, error) {
+	return uniqueNewsSlug(title, newsID)
+}
+
+// findEventBySlug retrieves an event by its slug, or by a slug it had before being renamed.
+// The returned event carries its current slug, which callers compare to the requested one to redirect.
+func findEventBySlug(slug string) (*models.Event, error) {
+	event, err := app.GetEventBySlug(slug)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return event, err
+	}
+
+	eventID, err := app.GetEventIDBySlugHistory(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := app.GetEventByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return app.GetEventBySlug(current.Slug)
+}
+
+// findNewsBySlug retrieves a news by its slug, or by a slug it had before being renamed
+func findNewsBySlug(slug string) (*models.News, error) {
+	news, err := app.GetNewsBySlug(slug)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return news, err
+	}
+
+	newsID, err := app.GetNewsIDBySlugHistory(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := app.GetNewsByID(newsID)
+	if err != nil {
+		return nil, err
+	}
+	return app.GetNewsBySlug(current.Slug)
+}
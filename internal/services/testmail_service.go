@@ -2,6 +2,7 @@ package services
 
 import (
 	"Backend/configs"
+	"Backend/internal/models"
 	"crypto/tls"
 	"fmt"
 	"github.com/google/uuid"
@@ -58,6 +59,20 @@ func (ts *TestMailService) SendVerificationEmail(to, token string, userId uuid.U
 	return ts.sendEmail(to, subject, body)
 }
 
+// SendEventStatusEmail tells a registrant that an event was cancelled or postponed
+func (ts *TestMailService) SendEventStatusEmail(to, name string, event *models.Event, reason string) error {
+	subject, body := generateEventStatusEmail(name, event, reason)
+
+	return ts.sendEmail(to, subject, body)
+}
+
+// SendEventReminderEmail reminds a registrant of an upcoming event
+func (ts *TestMailService) SendEventReminderEmail(to, name string, event *models.Event) error {
+	subject, body := generateEventReminderEmail(name, event)
+
+	return ts.sendEmail(to, subject, body)
+}
+
 // sendEmail sends an email using SMTP
 func (ts *TestMailService) sendEmail(toEmail, subject, body string) error {
 	log.Printf("Attempting to send email to: %s with subject: %s", toEmail, subject)
@@ -171,7 +186,7 @@ func generateOTPEmailHTML(otpCode string) string {
 </head>
 <body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
     <div style="text-align: center; margin-bottom: 20px;">
-        <img src="https://sg.pufacomputing.live/Logo%20Puma.png" alt="PUFA Computing Logo" width="150" style="max-width: 100%;">
+        <img src="https://sg.pufacomputing.live/Logo%%20Puma.png" alt="PUFA Computing Logo" width="150" style="max-width: 100%%;">
     </div>
     <div style="background-color: #f9f9f9; border-radius: 5px; padding: 20px; border-top: 3px solid #003CE5;">
         <h1 style="color: #000; text-align: center; margin-bottom: 20px;">Your OTP Code</h1>
@@ -201,7 +216,7 @@ func generateVerificationEmailHTML(verificationLink string) string {
 </head>
 <body style="font-family: Arial, sans-serif; line-height: 1.6; color: #333; max-width: 600px; margin: 0 auto; padding: 20px;">
     <div style="text-align: center; margin-bottom: 20px;">
-        <img src="https://sg.pufacomputing.live/Logo%20Puma.png" alt="PUFA Computing Logo" width="150" style="max-width: 100%;">
+        <img src="https://sg.pufacomputing.live/Logo%%20Puma.png" alt="PUFA Computing Logo" width="150" style="max-width: 100%%;">
     </div>
     <div style="background-color: #f9f9f9; border-radius: 5px; padding: 20px; border-top: 3px solid #003CE5;">
         <h1 style="color: #000; text-align: center; margin-bottom: 20px;">Verify Your Email</h1>
@@ -0,0 +1,10 @@
+ALTER TABLE event_registrations DROP COLUMN IF EXISTS team_id;
+
+DROP TABLE IF EXISTS event_team_invitations;
+DROP TABLE IF EXISTS event_team_members;
+DROP TABLE IF EXISTS event_teams;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS team_registration,
+DROP COLUMN IF EXISTS min_team_size,
+DROP COLUMN IF EXISTS max_team_size;
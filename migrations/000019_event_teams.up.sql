@@ -0,0 +1,43 @@
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS team_registration BOOLEAN NOT NULL DEFAULT FALSE,
+ADD COLUMN IF NOT EXISTS min_team_size INT,
+ADD COLUMN IF NOT EXISTS max_team_size INT;
+
+CREATE TABLE IF NOT EXISTS event_teams (
+    id SERIAL PRIMARY KEY,
+    event_id INT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    captain_id UUID NOT NULL REFERENCES users(id),
+    registered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS event_teams_event_name_idx ON event_teams (event_id, LOWER(name));
+
+-- event_id is duplicated here so a user can only belong to one team per event
+CREATE TABLE IF NOT EXISTS event_team_members (
+    id SERIAL PRIMARY KEY,
+    team_id INT NOT NULL REFERENCES event_teams(id) ON DELETE CASCADE,
+    event_id INT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS event_team_invitations (
+    id SERIAL PRIMARY KEY,
+    team_id INT NOT NULL REFERENCES event_teams(id) ON DELETE CASCADE,
+    event_id INT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    invitee_id UUID NOT NULL REFERENCES users(id),
+    invited_by UUID NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    responded_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS event_team_invitations_pending_idx ON event_team_invitations (team_id, invitee_id) WHERE status = 'pending';
+
+ALTER TABLE event_registrations
+ADD COLUMN IF NOT EXISTS team_id INT REFERENCES event_teams(id) ON DELETE SET NULL;
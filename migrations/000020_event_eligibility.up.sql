@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS event_eligibility_rules (
+    event_id INT PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    allowed_majors TEXT[] NOT NULL DEFAULT '{}',
+    allowed_years TEXT[] NOT NULL DEFAULT '{}',
+    allowed_role_ids INT[] NOT NULL DEFAULT '{}',
+    require_student_id_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    organization_members_only BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS event_registrations_event_id_idx;
+
+ALTER TABLE event_registrations
+DROP COLUMN IF EXISTS form_answers,
+DROP COLUMN IF EXISTS checked_in_at;
@@ -0,0 +1,5 @@
+ALTER TABLE event_registrations
+ADD COLUMN IF NOT EXISTS form_answers JSONB NOT NULL DEFAULT '{}',
+ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS event_registrations_event_id_idx ON event_registrations (event_id);
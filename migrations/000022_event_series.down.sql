@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS events_series_occurrence_idx;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS series_id,
+DROP COLUMN IF EXISTS occurrence_date;
+
+DROP TABLE IF EXISTS event_series;
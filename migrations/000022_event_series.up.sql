@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS event_series (
+    id SERIAL PRIMARY KEY,
+    title TEXT NOT NULL,
+    recurrence_rule TEXT NOT NULL,
+    exceptions DATE[] NOT NULL DEFAULT '{}',
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    user_id uuid NOT NULL REFERENCES users (id),
+    organization_id INT NOT NULL REFERENCES organizations (id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- occurrence_date is the date the rule generated, it stays the same when a single occurrence is moved
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS series_id INT REFERENCES event_series (id) ON DELETE SET NULL,
+ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS events_series_occurrence_idx ON events (series_id, occurrence_date) WHERE series_id IS NOT NULL;
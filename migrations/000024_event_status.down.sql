@@ -0,0 +1,9 @@
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_status_check;
+
+ALTER TABLE events ALTER COLUMN status DROP DEFAULT;
+
+UPDATE events SET status = CASE status
+    WHEN 'published' THEN 'Upcoming'
+    WHEN 'open' THEN 'Open'
+    ELSE 'Ended'
+END;
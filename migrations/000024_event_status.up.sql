@@ -0,0 +1,15 @@
+UPDATE events SET status = CASE status
+    WHEN 'Upcoming' THEN 'published'
+    WHEN 'Open' THEN 'open'
+    WHEN 'Ended' THEN 'ended'
+    WHEN 'Closed' THEN 'ended'
+    ELSE LOWER(status)
+END;
+
+UPDATE events SET status = 'published'
+WHERE status NOT IN ('draft', 'published', 'open', 'ended', 'cancelled', 'postponed');
+
+ALTER TABLE events ALTER COLUMN status SET DEFAULT 'draft';
+
+ALTER TABLE events
+ADD CONSTRAINT events_status_check CHECK (status IN ('draft', 'published', 'open', 'ended', 'cancelled', 'postponed'));
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS events_status_start_date_idx;
+DROP INDEX IF EXISTS events_status_end_date_idx;
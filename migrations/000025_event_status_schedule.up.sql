@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS events_status_start_date_idx ON events (status, start_date);
+CREATE INDEX IF NOT EXISTS events_status_end_date_idx ON events (status, end_date);
@@ -0,0 +1,11 @@
+ALTER TABLE event_series
+DROP COLUMN IF EXISTS timezone,
+ALTER COLUMN start_date TYPE DATE USING ((start_date AT TIME ZONE 'Asia/Jakarta')::date),
+ALTER COLUMN end_date TYPE DATE USING ((end_date AT TIME ZONE 'Asia/Jakarta')::date);
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS online_meeting_url,
+DROP COLUMN IF EXISTS venue,
+DROP COLUMN IF EXISTS timezone,
+ALTER COLUMN start_date TYPE DATE USING ((start_date AT TIME ZONE 'Asia/Jakarta')::date),
+ALTER COLUMN end_date TYPE DATE USING ((end_date AT TIME ZONE 'Asia/Jakarta')::date);
@@ -0,0 +1,12 @@
+-- Existing events only had dates, they become full days in Asia/Jakarta so their status stays the same
+ALTER TABLE events
+ALTER COLUMN start_date TYPE TIMESTAMP WITH TIME ZONE USING (start_date::timestamp AT TIME ZONE 'Asia/Jakarta'),
+ALTER COLUMN end_date TYPE TIMESTAMP WITH TIME ZONE USING ((end_date::timestamp + INTERVAL '1 day' - INTERVAL '1 second') AT TIME ZONE 'Asia/Jakarta'),
+ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta',
+ADD COLUMN IF NOT EXISTS venue TEXT,
+ADD COLUMN IF NOT EXISTS online_meeting_url TEXT;
+
+ALTER TABLE event_series
+ALTER COLUMN start_date TYPE TIMESTAMP WITH TIME ZONE USING (start_date::timestamp AT TIME ZONE 'Asia/Jakarta'),
+ALTER COLUMN end_date TYPE TIMESTAMP WITH TIME ZONE USING ((end_date::timestamp + INTERVAL '1 day' - INTERVAL '1 second') AT TIME ZONE 'Asia/Jakarta'),
+ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Jakarta';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS event_reminder_log;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS event_reminders_enabled;
@@ -0,0 +1,11 @@
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS event_reminders_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- One row per reminder sent, so a reminder is never sent twice to the same registrant
+CREATE TABLE IF NOT EXISTS event_reminder_log (
+    event_id INT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reminder VARCHAR(32) NOT NULL,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, user_id, reminder)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_feedback;
+DROP TABLE IF EXISTS event_feedback_surveys;
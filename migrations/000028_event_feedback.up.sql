@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS event_feedback_surveys (
+    event_id INT PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    questions JSONB NOT NULL DEFAULT '[]',
+    checked_in_only BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS event_feedback (
+    id SERIAL PRIMARY KEY,
+    event_id INT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    answers JSONB NOT NULL DEFAULT '{}',
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, user_id)
+);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS certificate_templates (
+    event_id INT PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    background_url TEXT,
+    orientation VARCHAR(16) NOT NULL DEFAULT 'landscape',
+    audience VARCHAR(16) NOT NULL DEFAULT 'checked_in',
+    fields JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS certificates (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(16) NOT NULL UNIQUE,
+    event_id INT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_name TEXT NOT NULL,
+    file_url TEXT NOT NULL,
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS certificates_user_id_idx ON certificates (user_id);
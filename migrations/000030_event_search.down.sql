@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS events_start_date_idx;
+DROP INDEX IF EXISTS events_search_idx;
@@ -0,0 +1,5 @@
+-- Full-text search over the title and description of events, the expression must match the one used by ListEvents
+CREATE INDEX IF NOT EXISTS events_search_idx ON events
+    USING GIN (to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(description, '')));
+
+CREATE INDEX IF NOT EXISTS events_start_date_idx ON events (start_date, id);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS events_primary_host ON events;
+DROP FUNCTION IF EXISTS sync_event_primary_host();
+DROP TABLE IF EXISTS event_hosts;
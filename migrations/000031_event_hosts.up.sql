@@ -0,0 +1,34 @@
+-- Organizations hosting an event. events.organization_id stays the primary host and is mirrored here by a trigger.
+CREATE TABLE IF NOT EXISTS event_hosts (
+    event_id INT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    organization_id INT NOT NULL REFERENCES organizations (id),
+    is_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (event_id, organization_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS event_hosts_primary_idx ON event_hosts (event_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS event_hosts_organization_id_idx ON event_hosts (organization_id);
+
+INSERT INTO event_hosts (event_id, organization_id, is_primary)
+SELECT id, organization_id, TRUE FROM events
+ON CONFLICT (event_id, organization_id) DO NOTHING;
+
+-- A new primary host keeps the previous one as a co-host
+CREATE OR REPLACE FUNCTION sync_event_primary_host() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE event_hosts SET is_primary = FALSE
+    WHERE event_id = NEW.id AND is_primary AND organization_id <> NEW.organization_id;
+
+    INSERT INTO event_hosts (event_id, organization_id, is_primary)
+    VALUES (NEW.id, NEW.organization_id, TRUE)
+    ON CONFLICT (event_id, organization_id) DO UPDATE SET is_primary = TRUE;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS events_primary_host ON events;
+CREATE TRIGGER events_primary_host
+    AFTER INSERT OR UPDATE OF organization_id ON events
+    FOR EACH ROW EXECUTE FUNCTION sync_event_primary_host();
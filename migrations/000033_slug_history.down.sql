@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS news_slug_history ON news;
+DROP TRIGGER IF EXISTS events_slug_history ON events;
+DROP FUNCTION IF EXISTS record_news_slug_history();
+DROP FUNCTION IF EXISTS record_event_slug_history();
+DROP TABLE IF EXISTS news_slug_history;
+DROP TABLE IF EXISTS event_slug_history;
+DROP INDEX IF EXISTS news_slug_idx;
+DROP INDEX IF EXISTS events_slug_idx;
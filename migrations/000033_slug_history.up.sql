@@ -0,0 +1,56 @@
+-- Existing duplicate slugs keep the oldest row, the others get their id appended
+UPDATE events e SET slug = e.slug || '-' || e.id
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS position FROM events) d
+WHERE d.id = e.id AND d.position > 1;
+
+UPDATE news n SET slug = n.slug || '-' || n.id
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS position FROM news) d
+WHERE d.id = n.id AND d.position > 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS events_slug_idx ON events (slug);
+CREATE UNIQUE INDEX IF NOT EXISTS news_slug_idx ON news (slug);
+
+-- Previous slugs of renamed events and news, so old links can be redirected to the current slug
+CREATE TABLE IF NOT EXISTS event_slug_history (
+    slug TEXT PRIMARY KEY,
+    event_id INT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    replaced_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS news_slug_history (
+    slug TEXT PRIMARY KEY,
+    news_id INT NOT NULL REFERENCES news (id) ON DELETE CASCADE,
+    replaced_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION record_event_slug_history() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.slug IS DISTINCT FROM OLD.slug THEN
+        DELETE FROM event_slug_history WHERE slug = NEW.slug;
+        INSERT INTO event_slug_history (slug, event_id) VALUES (OLD.slug, NEW.id)
+        ON CONFLICT (slug) DO UPDATE SET event_id = EXCLUDED.event_id, replaced_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION record_news_slug_history() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.slug IS DISTINCT FROM OLD.slug THEN
+        DELETE FROM news_slug_history WHERE slug = NEW.slug;
+        INSERT INTO news_slug_history (slug, news_id) VALUES (OLD.slug, NEW.id)
+        ON CONFLICT (slug) DO UPDATE SET news_id = EXCLUDED.news_id, replaced_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS events_slug_history ON events;
+CREATE TRIGGER events_slug_history
+    AFTER UPDATE OF slug ON events
+    FOR EACH ROW EXECUTE FUNCTION record_event_slug_history();
+
+DROP TRIGGER IF EXISTS news_slug_history ON news;
+CREATE TRIGGER news_slug_history
+    AFTER UPDATE OF slug ON news
+    FOR EACH ROW EXECUTE FUNCTION record_news_slug_history();
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS news_status_publish_date_idx;
+
+ALTER TABLE news DROP CONSTRAINT IF EXISTS news_status_check;
+
+ALTER TABLE news DROP COLUMN IF EXISTS status;
+
+ALTER TABLE news
+ALTER COLUMN publish_date TYPE TIMESTAMP USING (publish_date AT TIME ZONE 'UTC');
@@ -0,0 +1,14 @@
+-- Publish dates are compared with NOW() for scheduled publishing, they keep their offset from now on.
+-- Existing dates were read as UTC by those comparisons and stay the same instant.
+ALTER TABLE news
+ALTER COLUMN publish_date TYPE TIMESTAMP WITH TIME ZONE USING (publish_date AT TIME ZONE 'UTC');
+
+-- Existing news were shown right away, those dated in the future become scheduled
+ALTER TABLE news ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'draft';
+
+UPDATE news SET status = CASE WHEN publish_date > NOW() THEN 'scheduled' ELSE 'published' END;
+
+ALTER TABLE news
+ADD CONSTRAINT news_status_check CHECK (status IN ('draft', 'scheduled', 'published', 'archived'));
+
+CREATE INDEX IF NOT EXISTS news_status_publish_date_idx ON news (status, publish_date);
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS news_likes_counter ON news_likes;
+DROP FUNCTION IF EXISTS sync_news_likes();
+
+ALTER TABLE news ALTER COLUMN likes DROP NOT NULL;
+
+ALTER TABLE news_likes DROP CONSTRAINT IF EXISTS news_likes_news_id_fkey;
+ALTER TABLE news_likes
+ADD CONSTRAINT news_likes_news_id_fkey FOREIGN KEY (news_id) REFERENCES news (id);
@@ -0,0 +1,27 @@
+-- Likes go away with their news instead of blocking its deletion
+ALTER TABLE news_likes DROP CONSTRAINT IF EXISTS news_likes_news_id_fkey;
+ALTER TABLE news_likes
+ADD CONSTRAINT news_likes_news_id_fkey FOREIGN KEY (news_id) REFERENCES news (id) ON DELETE CASCADE;
+
+-- The counter was never maintained, it is rebuilt from the likes
+UPDATE news n SET likes = (SELECT COUNT(*) FROM news_likes l WHERE l.news_id = n.id);
+
+ALTER TABLE news ALTER COLUMN likes SET DEFAULT 0;
+ALTER TABLE news ALTER COLUMN likes SET NOT NULL;
+
+-- Keeps news.likes equal to the number of rows in news_likes
+CREATE OR REPLACE FUNCTION sync_news_likes() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE news SET likes = likes + 1 WHERE id = NEW.news_id;
+    ELSE
+        UPDATE news SET likes = GREATEST(likes - 1, 0) WHERE id = OLD.news_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS news_likes_counter ON news_likes;
+CREATE TRIGGER news_likes_counter
+AFTER INSERT OR DELETE ON news_likes
+FOR EACH ROW EXECUTE FUNCTION sync_news_likes();
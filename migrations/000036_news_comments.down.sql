@@ -0,0 +1,7 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name IN ('news:comment', 'news:moderate'));
+
+DELETE FROM permissions WHERE name IN ('news:comment', 'news:moderate');
+
+DROP TABLE IF EXISTS news_comment_reports;
+DROP TABLE IF EXISTS news_comments;
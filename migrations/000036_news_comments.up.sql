@@ -0,0 +1,54 @@
+CREATE TABLE IF NOT EXISTS news_comments (
+    id SERIAL PRIMARY KEY,
+    news_id INT NOT NULL REFERENCES news (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    -- Replies point to a top level comment, replies to replies are not allowed
+    parent_id INT REFERENCES news_comments (id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    hidden_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    hidden_at TIMESTAMP WITH TIME ZONE,
+    -- Deleted comments are kept while they have replies so the thread stays readable
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS news_comments_news_id_idx ON news_comments (news_id, created_at);
+CREATE INDEX IF NOT EXISTS news_comments_user_id_idx ON news_comments (user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS news_comment_reports (
+    id SERIAL PRIMARY KEY,
+    comment_id INT NOT NULL REFERENCES news_comments (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved', 'dismissed')),
+    resolved_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (comment_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS news_comment_reports_open_idx ON news_comment_reports (comment_id) WHERE status = 'open';
+
+INSERT INTO permissions (name, description)
+SELECT 'news:comment', 'Comment on news'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'news:comment');
+
+INSERT INTO permissions (name, description)
+SELECT 'news:moderate', 'Moderate news comments'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'news:moderate');
+
+-- Everyone who may like news may comment on them
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT rp.role_id, p.id
+FROM role_permissions rp
+JOIN permissions l ON l.id = rp.permission_id AND l.name = 'news:like'
+CROSS JOIN permissions p
+WHERE p.name = 'news:comment';
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+CROSS JOIN permissions p
+WHERE LOWER(r.name) = 'admin' AND p.name = 'news:moderate';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS news_tag_links;
+DROP TABLE IF EXISTS news_tags;
@@ -0,0 +1,18 @@
+-- Tags and categories share one taxonomy, kind tells them apart
+CREATE TABLE IF NOT EXISTS news_tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(120) NOT NULL UNIQUE,
+    kind TEXT NOT NULL DEFAULT 'tag' CHECK (kind IN ('tag', 'category')),
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS news_tag_links (
+    news_id INT NOT NULL REFERENCES news (id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES news_tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (news_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS news_tag_links_tag_id_idx ON news_tag_links (tag_id);
@@ -0,0 +1,6 @@
+ALTER TABLE news DROP CONSTRAINT IF EXISTS news_content_format_check;
+
+ALTER TABLE news DROP COLUMN IF EXISTS reading_time;
+ALTER TABLE news DROP COLUMN IF EXISTS excerpt;
+ALTER TABLE news DROP COLUMN IF EXISTS content_html;
+ALTER TABLE news DROP COLUMN IF EXISTS content_format;
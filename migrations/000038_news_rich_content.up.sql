@@ -0,0 +1,11 @@
+-- content keeps the source written by editors, content_html the sanitized rendering served to readers.
+-- Existing news were written with the HTML editor, they are rendered by the server on its next start.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS content_format TEXT NOT NULL DEFAULT 'markdown';
+ALTER TABLE news ADD COLUMN IF NOT EXISTS content_html TEXT;
+ALTER TABLE news ADD COLUMN IF NOT EXISTS excerpt TEXT NOT NULL DEFAULT '';
+ALTER TABLE news ADD COLUMN IF NOT EXISTS reading_time INT NOT NULL DEFAULT 0;
+
+UPDATE news SET content_format = 'html';
+
+ALTER TABLE news
+ADD CONSTRAINT news_content_format_check CHECK (content_format IN ('markdown', 'html'));
@@ -0,0 +1,8 @@
+DELETE FROM role_permissions
+WHERE permission_id IN (SELECT id FROM permissions WHERE name = 'media:upload');
+
+DELETE FROM permissions WHERE name = 'media:upload';
+
+DROP TABLE IF EXISTS event_media;
+DROP TABLE IF EXISTS news_media;
+DROP TABLE IF EXISTS media;
@@ -0,0 +1,42 @@
+-- Images embedded in the content of news and events. A media is linked to every article whose
+-- content references it, media left without links are removed by the server after a grace period.
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    url TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- Last time an article referencing the media was saved, the grace period starts from it
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS media_last_used_at_idx ON media (last_used_at);
+
+CREATE TABLE IF NOT EXISTS news_media (
+    news_id INT NOT NULL REFERENCES news (id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media (id) ON DELETE CASCADE,
+    PRIMARY KEY (news_id, media_id)
+);
+
+CREATE INDEX IF NOT EXISTS news_media_media_id_idx ON news_media (media_id);
+
+CREATE TABLE IF NOT EXISTS event_media (
+    event_id INT NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media (id) ON DELETE CASCADE,
+    PRIMARY KEY (event_id, media_id)
+);
+
+CREATE INDEX IF NOT EXISTS event_media_media_id_idx ON event_media (media_id);
+
+INSERT INTO permissions (name, description)
+SELECT 'media:upload', 'Upload images embedded in news and events'
+WHERE NOT EXISTS (SELECT 1 FROM permissions WHERE name = 'media:upload');
+
+-- Everyone who may write news or events may embed images in them
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT DISTINCT rp.role_id, p.id
+FROM role_permissions rp
+JOIN permissions w ON w.id = rp.permission_id AND w.name IN ('news:create', 'news:edit', 'events:create', 'events:edit')
+CROSS JOIN permissions p
+WHERE p.name = 'media:upload';
@@ -0,0 +1,22 @@
+-- Every saved version of the title, content and thumbnail of a news, numbered from 1 per news
+CREATE TABLE IF NOT EXISTS news_revisions (
+    id SERIAL PRIMARY KEY,
+    news_id INT NOT NULL REFERENCES news (id) ON DELETE CASCADE,
+    revision INT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL DEFAULT '',
+    content_format TEXT NOT NULL,
+    thumbnail TEXT NOT NULL DEFAULT '',
+    -- Thumbnails are replaced in place, so the one of a revision is copied under this R2 key to be restorable
+    thumbnail_key TEXT,
+    editor_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    -- Revision a rollback restored
+    restored_from INT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (news_id, revision)
+);
+
+-- The current state of existing news is their first revision
+INSERT INTO news_revisions (news_id, revision, title, content, content_format, thumbnail, editor_id, created_at)
+SELECT id, 1, title, COALESCE(content, ''), content_format, COALESCE(thumbnail, ''), user_id, COALESCE(updated_at, created_at, NOW())
+FROM news;
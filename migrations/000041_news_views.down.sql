@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS news_likes_created_at_idx;
+DROP TABLE IF EXISTS news_daily_views;
+ALTER TABLE news DROP COLUMN IF EXISTS views;
@@ -0,0 +1,13 @@
+-- Views are buffered by the server and added here periodically, so the counters lag behind by up to a minute
+ALTER TABLE news ADD COLUMN IF NOT EXISTS views BIGINT NOT NULL DEFAULT 0;
+
+-- Views per news and day, the trending ranking weighs recent days more
+CREATE TABLE IF NOT EXISTS news_daily_views (
+    news_id INT NOT NULL REFERENCES news (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (news_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS news_daily_views_day_idx ON news_daily_views (day);
+CREATE INDEX IF NOT EXISTS news_likes_created_at_idx ON news_likes (created_at);
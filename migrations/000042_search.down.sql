@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS aspiration_search_vector ON aspirations;
+DROP TRIGGER IF EXISTS event_search_vector ON events;
+DROP TRIGGER IF EXISTS news_search_vector ON news;
+
+DROP FUNCTION IF EXISTS aspiration_search_vector();
+DROP FUNCTION IF EXISTS event_search_vector();
+DROP FUNCTION IF EXISTS news_search_vector();
+
+DROP INDEX IF EXISTS aspirations_search_vector_idx;
+DROP INDEX IF EXISTS events_search_vector_idx;
+DROP INDEX IF EXISTS news_search_vector_idx;
+
+ALTER TABLE aspirations DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE news DROP COLUMN IF EXISTS search_vector;
+
+CREATE INDEX IF NOT EXISTS events_search_idx ON events
+    USING GIN (to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(description, '')));
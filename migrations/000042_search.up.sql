@@ -0,0 +1,57 @@
+-- Full-text search. The simple configuration is used as content is written in English as well as Indonesian,
+-- titles weigh more than bodies. Vectors are kept up to date by triggers.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+ALTER TABLE aspirations ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+CREATE OR REPLACE FUNCTION news_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('simple', regexp_replace(COALESCE(NEW.content, ''), '<[^>]*>', ' ', 'g')), 'B');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION event_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(NEW.description, '')), 'B');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Only the subject of aspirations is searchable, their message stays with the organization
+CREATE OR REPLACE FUNCTION aspiration_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := setweight(to_tsvector('simple', COALESCE(NEW.subject, '')), 'A');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS news_search_vector ON news;
+CREATE TRIGGER news_search_vector
+    BEFORE INSERT OR UPDATE OF title, content ON news
+    FOR EACH ROW EXECUTE FUNCTION news_search_vector();
+
+DROP TRIGGER IF EXISTS event_search_vector ON events;
+CREATE TRIGGER event_search_vector
+    BEFORE INSERT OR UPDATE OF title, description ON events
+    FOR EACH ROW EXECUTE FUNCTION event_search_vector();
+
+DROP TRIGGER IF EXISTS aspiration_search_vector ON aspirations;
+CREATE TRIGGER aspiration_search_vector
+    BEFORE INSERT OR UPDATE OF subject ON aspirations
+    FOR EACH ROW EXECUTE FUNCTION aspiration_search_vector();
+
+-- Fire the triggers once for existing rows
+UPDATE news SET title = title;
+UPDATE events SET title = title;
+UPDATE aspirations SET subject = subject;
+
+CREATE INDEX IF NOT EXISTS news_search_vector_idx ON news USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS events_search_vector_idx ON events USING GIN (search_vector);
+-- The list of events searches the vector as well, the expression index it used is replaced
+DROP INDEX IF EXISTS events_search_idx;
+CREATE INDEX IF NOT EXISTS aspirations_search_vector_idx ON aspirations USING GIN (search_vector);
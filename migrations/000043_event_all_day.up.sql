@@ -0,0 +1,7 @@
+-- All-day events are marked explicitly instead of being guessed from their times.
+-- Events running from midnight to 23:59:59 in their timezone, such as those migrated from dates, are all-day.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS all_day BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE events SET all_day = TRUE
+WHERE (start_date AT TIME ZONE timezone)::time = '00:00:00'
+  AND (end_date AT TIME ZONE timezone)::time = '23:59:59';
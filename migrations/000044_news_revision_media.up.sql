@@ -0,0 +1,16 @@
+-- Media referenced by the content of news revisions, they are kept as long as a revision that may be restored uses them
+CREATE TABLE IF NOT EXISTS news_revision_media (
+    revision_id INT NOT NULL REFERENCES news_revisions (id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media (id) ON DELETE CASCADE,
+    PRIMARY KEY (revision_id, media_id)
+);
+
+CREATE INDEX IF NOT EXISTS news_revision_media_media_id_idx ON news_revision_media (media_id);
+
+-- Existing revisions reference media through their URL
+INSERT INTO news_revision_media (revision_id, media_id)
+SELECT DISTINCT r.id, m.id
+FROM news_revisions r
+CROSS JOIN LATERAL regexp_matches(r.content, '/media/([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})\.jpg', 'g') AS reference
+JOIN media m ON m.id = reference[1]::uuid
+ON CONFLICT DO NOTHING;
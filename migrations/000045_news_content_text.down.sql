@@ -0,0 +1,17 @@
+CREATE OR REPLACE FUNCTION news_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('simple', regexp_replace(COALESCE(NEW.content, ''), '<[^>]*>', ' ', 'g')), 'B');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS news_search_vector ON news;
+CREATE TRIGGER news_search_vector
+    BEFORE INSERT OR UPDATE OF title, content ON news
+    FOR EACH ROW EXECUTE FUNCTION news_search_vector();
+
+UPDATE news SET title = title;
+
+ALTER TABLE news DROP COLUMN IF EXISTS content_text;
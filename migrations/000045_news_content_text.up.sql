@@ -0,0 +1,17 @@
+-- Search indexes and highlights the text readers see, without Markdown syntax, tags or HTML entities.
+-- It is derived from the rendering by the server, existing news get it on its next start.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS content_text TEXT;
+
+CREATE OR REPLACE FUNCTION news_search_vector() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(NEW.content_text, '')), 'B');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS news_search_vector ON news;
+CREATE TRIGGER news_search_vector
+    BEFORE INSERT OR UPDATE OF title, content_text ON news
+    FOR EACH ROW EXECUTE FUNCTION news_search_vector();
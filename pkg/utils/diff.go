@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"strings"
+)
+
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffLine is a line of a line by line comparison, Operation tells whether it is kept, added or removed
+type DiffLine struct {
+	Operation string `json:"op"`
+	Text      string `json:"text"`
+}
+
+// maxDiffCells bounds the memory used to compare two texts, beyond it the differing lines are
+// reported as removed then added instead of being matched
+const maxDiffCells = 4_000_000
+
+// DiffLines compares two texts line by line and returns the lines of after, interleaved with
+// the lines of before that were removed, using a longest common subsequence
+func DiffLines(before, after string) []DiffLine {
+	a := splitDiffLines(before)
+	b := splitDiffLines(after)
+
+	// Lines shared at the start and the end are kept as they are, which makes most edits cheap to compare
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	diff := make([]DiffLine, 0, len(a)+len(b))
+	for _, line := range b[:prefix] {
+		diff = append(diff, DiffLine{Operation: DiffEqual, Text: line})
+	}
+
+	diff = append(diff, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+
+	for _, line := range b[len(b)-suffix:] {
+		diff = append(diff, DiffLine{Operation: DiffEqual, Text: line})
+	}
+	return diff
+}
+
+func diffMiddle(a, b []string) []DiffLine {
+	var diff []DiffLine
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		for _, line := range a {
+			diff = append(diff, DiffLine{Operation: DiffDelete, Text: line})
+		}
+		for _, line := range b {
+			diff = append(diff, DiffLine{Operation: DiffInsert, Text: line})
+		}
+		return diff
+	}
+
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	common := make([][]int32, len(a)+1)
+	for i := range common {
+		common[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, DiffLine{Operation: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			diff = append(diff, DiffLine{Operation: DiffDelete, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{Operation: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{Operation: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, DiffLine{Operation: DiffInsert, Text: b[j]})
+	}
+	return diff
+}
+
+func splitDiffLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []DiffLine
+	}{
+		{
+			name:   "both empty",
+			before: "",
+			after:  "",
+			want:   []DiffLine{},
+		},
+		{
+			name:   "unchanged",
+			before: "a\nb",
+			after:  "a\nb\n",
+			want:   []DiffLine{{DiffEqual, "a"}, {DiffEqual, "b"}},
+		},
+		{
+			name:   "added to empty",
+			before: "",
+			after:  "a\nb",
+			want:   []DiffLine{{DiffInsert, "a"}, {DiffInsert, "b"}},
+		},
+		{
+			name:   "all removed",
+			before: "a\nb",
+			after:  "",
+			want:   []DiffLine{{DiffDelete, "a"}, {DiffDelete, "b"}},
+		},
+		{
+			name:   "line changed in the middle",
+			before: "a\nb\nc",
+			after:  "a\nx\nc",
+			want:   []DiffLine{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffInsert, "x"}, {DiffEqual, "c"}},
+		},
+		{
+			name:   "line inserted",
+			before: "a\nc",
+			after:  "a\nb\nc",
+			want:   []DiffLine{{DiffEqual, "a"}, {DiffInsert, "b"}, {DiffEqual, "c"}},
+		},
+		{
+			name:   "lines moved keep the longest common subsequence",
+			before: "a\nb\nc\nd",
+			after:  "b\nc\na\nd",
+			want:   []DiffLine{{DiffDelete, "a"}, {DiffEqual, "b"}, {DiffEqual, "c"}, {DiffInsert, "a"}, {DiffEqual, "d"}},
+		},
+		{
+			name:   "windows line endings",
+			before: "a\r\nb\r\n",
+			after:  "a\nb\n",
+			want:   []DiffLine{{DiffEqual, "a"}, {DiffEqual, "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffLines(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffLines(%q, %q) = %v, want %v", tt.before, tt.after, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package utils
 
+import (
+	"strconv"
+)
+
 type ErrorResponse struct {
 	Errors []ErrorDetail `json:"errors"`
 }
@@ -25,6 +29,10 @@ type AlreadyRegisteredError struct {
 	EventID int `json:"event_id"`
 }
 
+type TeamRegistrationRequiredError struct {
+	EventID int `json:"event_id"`
+}
+
 func (m MaxRegistrationReachedError) Error() string {
 	return "Maximum registration limit reached for event with ID: " + string(rune(m.EventID))
 }
@@ -40,3 +48,7 @@ func (b BadRequestError) Error() string {
 func (a AlreadyRegisteredError) Error() string {
 	return "User is already registered for event with ID: " + string(rune(a.EventID))
 }
+
+func (t TeamRegistrationRequiredError) Error() string {
+	return "Event with ID: " + strconv.Itoa(t.EventID) + " only accepts team registrations"
+}
@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"time"
+)
+
+// Feed is a syndication feed rendered by BuildRSS and BuildAtom
+type Feed struct {
+	Title       string
+	Description string
+	// Link is the page the feed mirrors, SelfURL the URL the feed is served from
+	Link    string
+	SelfURL string
+	Updated time.Time
+	Items   []FeedItem
+}
+
+// FeedItem is an entry of a Feed, ID is a stable identifier that never changes for the item
+type FeedItem struct {
+	ID            string
+	Title         string
+	Link          string
+	Summary       string
+	ContentHTML   string
+	Author        string
+	Categories    []string
+	Published     time.Time
+	Updated       time.Time
+	EnclosureURL  string
+	EnclosureType string
+}
+
+type rssDocument struct {
+	XMLName       xml.Name   `xml:"rss"`
+	Version       string     `xml:"version,attr"`
+	AtomNamespace string     `xml:"xmlns:atom,attr"`
+	ContentNS     string     `xml:"xmlns:content,attr"`
+	DublinCoreNS  string     `xml:"xmlns:dc,attr"`
+	Channel       rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	Categories  []string      `xml:"category"`
+	Description string        `xml:"description"`
+	Content     *xmlCDATA     `xml:"content:encoded,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type xmlCDATA struct {
+	Value string `xml:",cdata"`
+}
+
+// BuildRSS renders a feed as RSS 2.0, the HTML of the items goes in content:encoded
+func BuildRSS(feed *Feed) ([]byte, error) {
+	document := rssDocument{
+		Version:       "2.0",
+		AtomNamespace: "http://www.w3.org/2005/Atom",
+		ContentNS:     "http://purl.org/rss/1.0/modules/content/",
+		DublinCoreNS:  "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       feed.Title,
+			Link:        feed.Link,
+			Description: feed.Description,
+			SelfLink:    atomLink{Href: feed.SelfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]rssItem, 0, len(feed.Items)),
+		},
+	}
+	if !feed.Updated.IsZero() {
+		document.Channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range feed.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{IsPermaLink: "false", Value: item.ID},
+			PubDate:     item.Published.UTC().Format(time.RFC1123Z),
+			Creator:     item.Author,
+			Categories:  item.Categories,
+			Description: item.Summary,
+		}
+		if item.ContentHTML != "" {
+			entry.Content = &xmlCDATA{Value: item.ContentHTML}
+		}
+		if item.EnclosureURL != "" {
+			// RSS requires a length, 0 tells readers it is unknown
+			entry.Enclosure = &rssEnclosure{URL: item.EnclosureURL, Length: "0", Type: item.EnclosureType}
+		}
+		document.Channel.Items = append(document.Channel.Items, entry)
+	}
+
+	return marshalFeed(document)
+}
+
+type atomDocument struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     *atomAuthor    `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    *atomContent   `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// BuildAtom renders a feed as Atom 1.0, the feed is identified by its SelfURL
+func BuildAtom(feed *Feed) ([]byte, error) {
+	// Atom requires an update date, a feed without entries is dated at the Unix epoch so its body and ETag never change
+	updated := feed.Updated
+	if updated.IsZero() {
+		updated = time.Unix(0, 0)
+	}
+
+	document := atomDocument{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		ID:       feed.SelfURL,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Updated:  updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]atomEntry, 0, len(feed.Items)),
+	}
+
+	for _, item := range feed.Items {
+		entry := atomEntry{
+			ID:        item.ID,
+			Title:     item.Title,
+			Links:     []atomLink{{Href: item.Link, Rel: "alternate", Type: "text/html"}},
+			Published: item.Published.UTC().Format(time.RFC3339),
+			Updated:   item.Updated.UTC().Format(time.RFC3339),
+			Summary:   item.Summary,
+		}
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		} else {
+			// Atom requires an author, the feed title stands for it
+			entry.Author = &atomAuthor{Name: feed.Title}
+		}
+		for _, category := range item.Categories {
+			entry.Categories = append(entry.Categories, atomCategory{Term: category})
+		}
+		if item.ContentHTML != "" {
+			entry.Content = &atomContent{Type: "html", Value: item.ContentHTML}
+		}
+		if item.EnclosureURL != "" {
+			entry.Links = append(entry.Links, atomLink{Href: item.EnclosureURL, Rel: "enclosure", Type: item.EnclosureType})
+		}
+		document.Entries = append(document.Entries, entry)
+	}
+
+	return marshalFeed(document)
+}
+
+func marshalFeed(document interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// FeedETag returns a strong entity tag for the body of a feed
+func FeedETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBuildAtomEmptyFeedIsStable(t *testing.T) {
+	feed := &Feed{Title: "News", Link: "https://example.com/news", SelfURL: "https://example.com/api/v1/news/feeds/atom"}
+
+	first, err := BuildAtom(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := BuildAtom(feed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("BuildAtom() of an empty feed changed between calls:\n%s\n%s", first, second)
+	}
+	if FeedETag(first) != FeedETag(second) {
+		t.Error("FeedETag() of an empty feed changed between calls")
+	}
+	if !bytes.Contains(first, []byte("<updated>1970-01-01T00:00:00Z</updated>")) {
+		t.Errorf("BuildAtom() of an empty feed is not dated at the epoch:\n%s", first)
+	}
+}
@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// CalendarTimeZone is the timezone events are held in, it matches the timezone of the database
+const CalendarTimeZone = "Asia/Jakarta"
+
+// CalendarLocation returns the location of CalendarTimeZone, falling back to a fixed UTC+7 zone when tzdata is missing
+func CalendarLocation() *time.Location {
+	location, err := time.LoadLocation(CalendarTimeZone)
+	if err != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return location
+}
+
+// ICalEvent is a single VEVENT of an iCalendar file
+type ICalEvent struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	URL          string
+	Categories   string
+	Status       string
+	Start        time.Time
+	End          time.Time
+	TimeZone     string
+	AllDay       bool
+	Created      time.Time
+	LastModified time.Time
+}
+
+// BuildICalendar renders events as an RFC 5545 calendar. Timed events in CalendarTimeZone are written with its TZID,
+// events in other timezones are written in UTC so no VTIMEZONE has to be described for them.
+// The dates of all-day events are taken in the location of Start and End.
+func BuildICalendar(name string, events []ICalEvent) []byte {
+	var buf bytes.Buffer
+	location := CalendarLocation()
+	now := time.Now().UTC()
+
+	writeICalLine(&buf, "BEGIN:VCALENDAR")
+	writeICalLine(&buf, "VERSION:2.0")
+	writeICalLine(&buf, "PRODID:-//PUFA Computer Science//Events//EN")
+	writeICalLine(&buf, "CALSCALE:GREGORIAN")
+	writeICalLine(&buf, "METHOD:PUBLISH")
+	writeICalLine(&buf, "X-WR-CALNAME:"+escapeICalText(name))
+	writeICalLine(&buf, "X-WR-TIMEZONE:"+CalendarTimeZone)
+
+	// Asia/Jakarta has no daylight saving time, a single standard rule describes it
+	writeICalLine(&buf, "BEGIN:VTIMEZONE")
+	writeICalLine(&buf, "TZID:"+CalendarTimeZone)
+	writeICalLine(&buf, "BEGIN:STANDARD")
+	writeICalLine(&buf, "DTSTART:19700101T000000")
+	writeICalLine(&buf, "TZOFFSETFROM:+0700")
+	writeICalLine(&buf, "TZOFFSETTO:+0700")
+	writeICalLine(&buf, "TZNAME:WIB")
+	writeICalLine(&buf, "END:STANDARD")
+	writeICalLine(&buf, "END:VTIMEZONE")
+
+	for _, event := range events {
+		writeICalLine(&buf, "BEGIN:VEVENT")
+		writeICalLine(&buf, "UID:"+event.UID)
+		writeICalLine(&buf, "DTSTAMP:"+now.Format("20060102T150405Z"))
+
+		if event.AllDay {
+			writeICalLine(&buf, "DTSTART;VALUE=DATE:"+event.Start.Format("20060102"))
+			writeICalLine(&buf, "DTEND;VALUE=DATE:"+event.End.Format("20060102"))
+		} else if event.TimeZone != "" && event.TimeZone != CalendarTimeZone {
+			writeICalLine(&buf, "DTSTART:"+event.Start.UTC().Format("20060102T150405Z"))
+			writeICalLine(&buf, "DTEND:"+event.End.UTC().Format("20060102T150405Z"))
+		} else {
+			writeICalLine(&buf, "DTSTART;TZID="+CalendarTimeZone+":"+event.Start.In(location).Format("20060102T150405"))
+			writeICalLine(&buf, "DTEND;TZID="+CalendarTimeZone+":"+event.End.In(location).Format("20060102T150405"))
+		}
+
+		writeICalLine(&buf, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&buf, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if event.Location != "" {
+			writeICalLine(&buf, "LOCATION:"+escapeICalText(event.Location))
+		}
+		if event.URL != "" {
+			writeICalLine(&buf, "URL:"+event.URL)
+		}
+		if event.Categories != "" {
+			writeICalLine(&buf, "CATEGORIES:"+escapeICalText(event.Categories))
+		}
+		if event.Status != "" {
+			writeICalLine(&buf, "STATUS:"+event.Status)
+		}
+		if !event.Created.IsZero() {
+			writeICalLine(&buf, "CREATED:"+event.Created.UTC().Format("20060102T150405Z"))
+		}
+		if !event.LastModified.IsZero() {
+			writeICalLine(&buf, "LAST-MODIFIED:"+event.LastModified.UTC().Format("20060102T150405Z"))
+		}
+		writeICalLine(&buf, "END:VEVENT")
+	}
+
+	writeICalLine(&buf, "END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// escapeICalText escapes a TEXT value as required by RFC 5545
+func escapeICalText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	return replacer.Replace(value)
+}
+
+// writeICalLine writes a content line folded at 75 octets without splitting UTF-8 characters
+func writeICalLine(buf *bytes.Buffer, line string) {
+	// Continuation lines start with a space, which counts towards their length
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,236 @@
+package utils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// RenderMarkdown renders the subset of Markdown editors use for news to HTML: headings, paragraphs,
+// emphasis, inline code, fenced code blocks, links, images, lists, blockquotes and rules.
+// Raw HTML in the source is escaped, the result is meant to go through SanitizeHTML as well.
+func RenderMarkdown(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	var out strings.Builder
+	renderMarkdownBlocks(&out, strings.Split(source, "\n"))
+	return out.String()
+}
+
+var (
+	markdownHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownRule        = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	markdownFence       = regexp.MustCompile("^\\s{0,3}(```|~~~)\\s*([\\w+-]*)")
+	markdownBullet      = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	markdownNumbered    = regexp.MustCompile(`^\s{0,3}(\d{1,9})[.)]\s+(.*)$`)
+	markdownQuote       = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	markdownLinkPattern = regexp.MustCompile(`^\[([^\]]*)\]\(\s*<?([^\s)>]*)>?(?:\s+"[^"]*")?\s*\)`)
+)
+
+func renderMarkdownBlocks(out *strings.Builder, lines []string) {
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>")
+			for i, line := range paragraph {
+				if i > 0 {
+					// Two trailing spaces or a backslash end a line with a hard break
+					if strings.HasSuffix(paragraph[i-1], "  ") || strings.HasSuffix(paragraph[i-1], "\\") {
+						out.WriteString("<br>")
+					}
+					out.WriteString("\n")
+				}
+				out.WriteString(renderMarkdownInline(strings.TrimRight(strings.TrimSpace(line), "\\")))
+			}
+			out.WriteString("</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			continue
+		}
+
+		if match := markdownFence.FindStringSubmatch(line); match != nil {
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), match[1]); i++ {
+				code = append(code, lines[i])
+			}
+			if match[2] != "" {
+				out.WriteString(`<pre><code class="language-` + html.EscapeString(match[2]) + `">`)
+			} else {
+				out.WriteString("<pre><code>")
+			}
+			out.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			out.WriteString("</code></pre>\n")
+			continue
+		}
+
+		if match := markdownHeading.FindStringSubmatch(line); match != nil {
+			flush()
+			level := string(rune('0' + len(match[1])))
+			out.WriteString("<h" + level + ">" + renderMarkdownInline(match[2]) + "</h" + level + ">\n")
+			continue
+		}
+
+		if markdownRule.MatchString(line) {
+			flush()
+			out.WriteString("<hr>\n")
+			continue
+		}
+
+		if markdownQuote.MatchString(line) {
+			flush()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				match := markdownQuote.FindStringSubmatch(lines[i])
+				if match == nil {
+					break
+				}
+				quoted = append(quoted, match[1])
+			}
+			i--
+			out.WriteString("<blockquote>\n")
+			renderMarkdownBlocks(out, quoted)
+			out.WriteString("</blockquote>\n")
+			continue
+		}
+
+		bullet, numbered := markdownBullet.FindStringSubmatch(line), markdownNumbered.FindStringSubmatch(line)
+		if bullet != nil || numbered != nil {
+			flush()
+			item, tag := markdownBullet, "ul"
+			if bullet == nil {
+				item, tag = markdownNumbered, "ol"
+				if numbered[1] != "1" {
+					out.WriteString(`<ol start="` + strings.TrimLeft(numbered[1], "0") + `">` + "\n")
+				} else {
+					out.WriteString("<ol>\n")
+				}
+			} else {
+				out.WriteString("<ul>\n")
+			}
+
+			var items []string
+			for ; i < len(lines); i++ {
+				if match := item.FindStringSubmatch(lines[i]); match != nil {
+					items = append(items, match[len(match)-1])
+					continue
+				}
+				// Indented lines continue the previous item
+				if strings.TrimSpace(lines[i]) != "" && strings.HasPrefix(lines[i], " ") && len(items) > 0 {
+					items[len(items)-1] += " " + strings.TrimSpace(lines[i])
+					continue
+				}
+				break
+			}
+			i--
+
+			for _, text := range items {
+				out.WriteString("<li>" + renderMarkdownInline(text) + "</li>\n")
+			}
+			out.WriteString("</" + tag + ">\n")
+			continue
+		}
+
+		paragraph = append(paragraph, line)
+	}
+	flush()
+}
+
+// renderMarkdownInline renders emphasis, code spans, links and images of a line of text
+func renderMarkdownInline(text string) string {
+	var out strings.Builder
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.ContainsRune("\\`*_{}[]()#+-.!<>", rune(text[i+1])):
+			out.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			ticks := len(text[i:]) - len(strings.TrimLeft(text[i:], "`"))
+			fence := strings.Repeat("`", ticks)
+			if end := strings.Index(text[i+ticks:], fence); end >= 0 {
+				code := strings.TrimSpace(text[i+ticks : i+ticks+end])
+				out.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i += ticks + end + ticks
+				continue
+			}
+
+		case c == '!' && strings.HasPrefix(text[i+1:], "["):
+			if match := markdownLinkPattern.FindStringSubmatch(text[i+1:]); match != nil {
+				if url, ok := safeMarkdownURL(match[2]); ok {
+					out.WriteString(`<img src="` + html.EscapeString(url) + `" alt="` + html.EscapeString(match[1]) + `">`)
+				} else {
+					out.WriteString(html.EscapeString(match[1]))
+				}
+				i += 1 + len(match[0])
+				continue
+			}
+
+		case c == '[':
+			if match := markdownLinkPattern.FindStringSubmatch(text[i:]); match != nil {
+				label := renderMarkdownInline(match[1])
+				if url, ok := safeMarkdownURL(match[2]); ok {
+					out.WriteString(`<a href="` + html.EscapeString(url) + `">` + label + "</a>")
+				} else {
+					out.WriteString(label)
+				}
+				i += len(match[0])
+				continue
+			}
+
+		case c == '<':
+			// Autolinks such as <https://example.com>
+			if end := strings.IndexByte(text[i:], '>'); end > 0 {
+				target := text[i+1 : i+end]
+				if url, ok := safeMarkdownURL(target); ok && strings.Contains(target, ":") && !strings.ContainsAny(target, " \t") {
+					out.WriteString(`<a href="` + html.EscapeString(url) + `">` + html.EscapeString(target) + "</a>")
+					i += end + 1
+					continue
+				}
+			}
+
+		case c == '*' || c == '_' && (i == 0 || !isMarkdownWordByte(text[i-1])):
+			// Underscores inside words, as in snake_case, are not emphasis
+			delimiter := string(c)
+			tag := "em"
+			if strings.HasPrefix(text[i:], delimiter+delimiter) {
+				delimiter += delimiter
+				tag = "strong"
+			}
+			rest := text[i+len(delimiter):]
+			// Emphasis must hug its text, "a * b" stays as it is
+			if rest != "" && rest[0] != ' ' {
+				if end := strings.Index(rest, delimiter); end > 0 && rest[end-1] != ' ' {
+					out.WriteString("<" + tag + ">" + renderMarkdownInline(rest[:end]) + "</" + tag + ">")
+					i += len(delimiter) + end + len(delimiter)
+					continue
+				}
+			}
+		}
+
+		out.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+	return out.String()
+}
+
+// safeMarkdownURL accepts web and mail links as well as relative ones, javascript: and data: URLs are refused
+func safeMarkdownURL(url string) (string, bool) {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return "", false
+	}
+	return url, isSafeURL(url)
+}
+
+func isMarkdownWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,85 @@
+package utils
+
+import "testing"
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{
+			name:   "paragraphs",
+			source: "first\nline\n\nsecond",
+			want:   "<p>first\nline</p>\n<p>second</p>\n",
+		},
+		{
+			name:   "hard break",
+			source: "a  \nb",
+			want:   "<p>a<br>\nb</p>\n",
+		},
+		{
+			name:   "headings",
+			source: "## Title ##",
+			want:   "<h2>Title</h2>\n",
+		},
+		{
+			name:   "emphasis",
+			source: "**bold** and *italic* and snake_case_name",
+			want:   "<p><strong>bold</strong> and <em>italic</em> and snake_case_name</p>\n",
+		},
+		{
+			name:   "loose asterisks are not emphasis",
+			source: "a * b * c",
+			want:   "<p>a * b * c</p>\n",
+		},
+		{
+			name:   "inline code is escaped",
+			source: "use `<b>`",
+			want:   "<p>use <code>&lt;b&gt;</code></p>\n",
+		},
+		{
+			name:   "fenced code",
+			source: "```go\nx := 1 < 2\n```",
+			want:   "<pre><code class=\"language-go\">x := 1 &lt; 2</code></pre>\n",
+		},
+		{
+			name:   "links and images",
+			source: "[site](https://example.com) ![logo](/logo.png)",
+			want:   "<p><a href=\"https://example.com\">site</a> <img src=\"/logo.png\" alt=\"logo\"></p>\n",
+		},
+		{
+			name:   "javascript links keep their label only",
+			source: "[click](javascript:void)",
+			want:   "<p>click</p>\n",
+		},
+		{
+			name:   "autolinks",
+			source: "<https://example.com>",
+			want:   "<p><a href=\"https://example.com\">https://example.com</a></p>\n",
+		},
+		{
+			name:   "raw html is escaped",
+			source: "<script>alert(1)</script>",
+			want:   "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+		},
+		{
+			name:   "lists",
+			source: "- a\n- b\n\n3. c\n4. d",
+			want:   "<ul>\n<li>a</li>\n<li>b</li>\n</ul>\n<ol start=\"3\">\n<li>c</li>\n<li>d</li>\n</ol>\n",
+		},
+		{
+			name:   "blockquotes and rules",
+			source: "> quoted\n\n---",
+			want:   "<blockquote>\n<p>quoted</p>\n</blockquote>\n<hr>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.source); got != tt.want {
+				t.Errorf("RenderMarkdown(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"math"
+	"strconv"
+)
+
+// Page sizes in points
+const (
+	PDFA4Width  = 595.28
+	PDFA4Height = 841.89
+)
+
+const (
+	PDFAlignLeft   = "left"
+	PDFAlignCenter = "center"
+	PDFAlignRight  = "right"
+)
+
+// helveticaWidths and helveticaBoldWidths are the widths of the printable ASCII characters (32 to 126)
+// of the standard Helvetica fonts in thousandths of the font size, as listed in their AFM metrics
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// PDFPage builds a single page PDF with an optional JPEG background and text in the standard Helvetica fonts.
+// Text is limited to the Latin-1 range, other characters are replaced by a question mark.
+type PDFPage struct {
+	width      float64
+	height     float64
+	background []byte
+	imageInfo  image.Config
+	content    bytes.Buffer
+}
+
+// NewPDFPage starts a page of the given size in points
+func NewPDFPage(width, height float64) *PDFPage {
+	return &PDFPage{width: width, height: height}
+}
+
+// SetBackgroundJPEG stretches a JPEG image over the whole page
+func (p *PDFPage) SetBackgroundJPEG(data []byte) error {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if format != "jpeg" {
+		return errors.New("the background must be a JPEG image")
+	}
+
+	p.background = data
+	p.imageInfo = config
+	return nil
+}
+
+// DrawText writes a line of text with its baseline at y, measured in points from the bottom of the page.
+// The alignment tells whether x is the left edge, the center or the right edge of the text.
+func (p *PDFPage) DrawText(text string, x, y, size float64, bold bool, align string, rgb [3]float64) {
+	encoded := encodeWinAnsi(text)
+
+	switch align {
+	case PDFAlignCenter:
+		x -= PDFTextWidth(text, size, bold) / 2
+	case PDFAlignRight:
+		x -= PDFTextWidth(text, size, bold)
+	}
+
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s %s rg %s %s Td (%s) Tj ET\n",
+		font, pdfNumber(size), pdfNumber(rgb[0]), pdfNumber(rgb[1]), pdfNumber(rgb[2]),
+		pdfNumber(x), pdfNumber(y), escapePDFString(encoded))
+}
+
+// PDFTextWidth measures a line of text in points, characters outside printable ASCII count as an average glyph
+func PDFTextWidth(text string, size float64, bold bool) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+
+	total := 0
+	for _, r := range text {
+		if r >= 32 && r <= 126 {
+			total += widths[r-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// Bytes renders the page as a complete PDF file
+func (p *PDFPage) Bytes() []byte {
+	var content bytes.Buffer
+	if p.background != nil {
+		fmt.Fprintf(&content, "q %s 0 0 %s 0 0 cm /Im1 Do Q\n", pdfNumber(p.width), pdfNumber(p.height))
+	}
+	content.Write(p.content.Bytes())
+
+	resources := "/Font << /F1 5 0 R /F2 6 0 R >>"
+	if p.background != nil {
+		resources += " /XObject << /Im1 7 0 R >>"
+	}
+
+	objects := [][]byte{
+		[]byte("<< /Type /Catalog /Pages 2 0 R >>"),
+		[]byte("<< /Type /Pages /Kids [3 0 R] /Count 1 >>"),
+		[]byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s >> /Contents 4 0 R >>",
+			pdfNumber(p.width), pdfNumber(p.height), resources)),
+		pdfStream(fmt.Sprintf("<< /Length %d >>", content.Len()), content.Bytes()),
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"),
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"),
+	}
+
+	if p.background != nil {
+		colorSpace := "/DeviceRGB"
+		switch p.imageInfo.ColorModel {
+		case color.GrayModel:
+			colorSpace = "/DeviceGray"
+		case color.CMYKModel:
+			// Adobe JPEGs store CMYK inverted
+			colorSpace = "/DeviceCMYK /Decode [1 0 1 0 1 0 1 0]"
+		}
+
+		objects = append(objects, pdfStream(
+			fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>",
+				p.imageInfo.Width, p.imageInfo.Height, colorSpace, len(p.background)),
+			p.background))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		buf.Write(object)
+		buf.WriteString("\nendobj\n")
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+func pdfStream(dictionary string, data []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(dictionary)
+	buf.WriteString("\nstream\n")
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+	return buf.Bytes()
+}
+
+func pdfNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// winAnsiPunctuation maps the typographic characters WinAnsiEncoding adds to Latin-1
+var winAnsiPunctuation = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// encodeWinAnsi converts text to WinAnsiEncoding, which matches Latin-1 apart from some punctuation
+func encodeWinAnsi(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r >= 32 && r <= 126, r >= 160 && r <= 255:
+			encoded = append(encoded, byte(r))
+		case winAnsiPunctuation[r] != 0:
+			encoded = append(encoded, winAnsiPunctuation[r])
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+func escapePDFString(text []byte) string {
+	var buf bytes.Buffer
+	for _, b := range text {
+		if b == '(' || b == ')' || b == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(b)
+	}
+	return buf.String()
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestEncodeWinAnsi(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []byte
+	}{
+		{"ascii", "Hello, World!", []byte("Hello, World!")},
+		{"latin-1", "Café Ñ", []byte{'C', 'a', 'f', 0xE9, ' ', 0xD1}},
+		{"typographic punctuation", "“Quote” – €5…", []byte{0x93, 'Q', 'u', 'o', 't', 'e', 0x94, ' ', 0x96, ' ', 0x80, '5', 0x85}},
+		{"outside the encoding", "日本 ✓", []byte("?? ?")},
+		{"control characters", "a\tb\n", []byte("a?b?")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeWinAnsi(tt.text); !bytes.Equal(got, tt.want) {
+				t.Errorf("encodeWinAnsi(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapePDFString(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"plain", "plain"},
+		{"(parentheses)", `\(parentheses\)`},
+		{`back\slash`, `back\\slash`},
+		{`)\(`, `\)\\\(`},
+	}
+
+	for _, tt := range tests {
+		if got := escapePDFString([]byte(tt.text)); got != tt.want {
+			t.Errorf("escapePDFString(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestPDFTextWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		size float64
+		bold bool
+		want float64
+	}{
+		{"empty", "", 12, false, 0},
+		{"regular", "Hi", 10, false, (722 + 222) * 10.0 / 1000},
+		{"bold", "Hi", 10, true, (722 + 278) * 10.0 / 1000},
+		{"space and tilde", " ~", 20, false, (278 + 584) * 20.0 / 1000},
+		{"outside ascii counts as an average glyph", "é", 10, false, 5.56},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PDFTextWidth(tt.text, tt.size, tt.bold); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("PDFTextWidth(%q, %v, %v) = %v, want %v", tt.text, tt.size, tt.bold, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPDFPageBytesXref(t *testing.T) {
+	var background bytes.Buffer
+	if err := jpeg.Encode(&background, image.NewRGBA(image.Rect(0, 0, 4, 3)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		background []byte
+		objects    int
+	}{
+		{"text only", nil, 6},
+		{"with a background", background.Bytes(), 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewPDFPage(PDFA4Width, PDFA4Height)
+			if tt.background != nil {
+				if err := page.SetBackgroundJPEG(tt.background); err != nil {
+					t.Fatal(err)
+				}
+			}
+			page.DrawText("Certificate (of) \\ participation", 100, 200, 24, true, PDFAlignCenter, [3]float64{0, 0, 0})
+			pdf := page.Bytes()
+
+			match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(pdf)
+			if match == nil {
+				t.Fatalf("Bytes() does not end with startxref:\n%s", pdf)
+			}
+			xref, _ := strconv.Atoi(string(match[1]))
+			if !bytes.HasPrefix(pdf[xref:], []byte(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", tt.objects+1))) {
+				t.Fatalf("startxref %d does not point at the cross-reference table", xref)
+			}
+
+			entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(pdf[xref:], -1)
+			if len(entries) != tt.objects {
+				t.Fatalf("cross-reference table has %d entries, want %d", len(entries), tt.objects)
+			}
+			for i, entry := range entries {
+				offset, _ := strconv.Atoi(string(entry[1]))
+				if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(pdf[offset:], []byte(want)) {
+					t.Errorf("offset %d of object %d points at %q", offset, i+1, pdf[offset:offset+len(want)])
+				}
+			}
+
+			if !bytes.Contains(pdf, []byte(`(Certificate \(of\) \\ participation) Tj`)) {
+				t.Error("Bytes() does not contain the escaped text")
+			}
+		})
+	}
+}
+
+func TestSetBackgroundJPEGRejectsOtherFormats(t *testing.T) {
+	page := NewPDFPage(PDFA4Width, PDFA4Height)
+	if err := page.SetBackgroundJPEG([]byte("not an image")); err == nil {
+		t.Error("SetBackgroundJPEG() accepted data that is not a JPEG image")
+	}
+}
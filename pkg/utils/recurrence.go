@@ -0,0 +1,278 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRecurrenceOccurrences limits how many occurrences a single rule may produce
+const MaxRecurrenceOccurrences = 100
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RecurrenceDay is a BYDAY entry, Ordinal is only used by monthly rules (1MO is the first Monday, -1FR the last Friday)
+type RecurrenceDay struct {
+	Weekday time.Weekday
+	Ordinal int
+}
+
+// RecurrenceRule is the supported subset of an iCalendar RRULE:
+// FREQ=WEEKLY|MONTHLY, INTERVAL, COUNT, UNTIL, BYDAY and BYMONTHDAY.
+type RecurrenceRule struct {
+	Frequency  string
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+}
+
+// ParseRecurrenceRule parses an RRULE such as "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10", the "RRULE:" prefix is optional
+func ParseRecurrenceRule(rule string) (*RecurrenceRule, error) {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return nil, BadRequestError{Message: "Recurrence rule is required"}
+	}
+
+	r := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		if part == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, BadRequestError{Message: "Invalid recurrence rule part: " + part}
+		}
+
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			r.Frequency = strings.ToUpper(value)
+			if r.Frequency != "WEEKLY" && r.Frequency != "MONTHLY" {
+				return nil, BadRequestError{Message: "Only WEEKLY and MONTHLY recurrences are supported"}
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, BadRequestError{Message: "INTERVAL must be a positive number"}
+			}
+			r.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, BadRequestError{Message: "COUNT must be a positive number"}
+			}
+			r.Count = count
+		case "UNTIL":
+			until, err := parseRecurrenceUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(strings.ToUpper(value), ",") {
+				parsed, err := parseRecurrenceDay(day)
+				if err != nil {
+					return nil, err
+				}
+				r.ByDay = append(r.ByDay, parsed)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return nil, BadRequestError{Message: "Invalid BYMONTHDAY value: " + day}
+				}
+				r.ByMonthDay = append(r.ByMonthDay, monthDay)
+			}
+		case "WKST":
+			// Weeks always start on Monday
+		default:
+			return nil, BadRequestError{Message: "Unsupported recurrence rule part: " + name}
+		}
+	}
+
+	if r.Frequency == "" {
+		return nil, BadRequestError{Message: "FREQ is required in the recurrence rule"}
+	}
+	if r.Count == 0 && r.Until == nil {
+		return nil, BadRequestError{Message: "Recurrence rule must end with COUNT or UNTIL"}
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, BadRequestError{Message: "COUNT and UNTIL cannot be used together"}
+	}
+	if r.Count > MaxRecurrenceOccurrences {
+		return nil, BadRequestError{Message: fmt.Sprintf("A series cannot have more than %d occurrences", MaxRecurrenceOccurrences)}
+	}
+	if r.Frequency == "WEEKLY" && len(r.ByMonthDay) > 0 {
+		return nil, BadRequestError{Message: "BYMONTHDAY is only supported for MONTHLY recurrences"}
+	}
+	for _, day := range r.ByDay {
+		if day.Ordinal != 0 && r.Frequency != "MONTHLY" {
+			return nil, BadRequestError{Message: "Numbered BYDAY values are only supported for MONTHLY recurrences"}
+		}
+	}
+
+	return r, nil
+}
+
+func parseRecurrenceUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102", "2006-01-02"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if len(value) <= len("2006-01-02") {
+				// A date without time includes the whole day
+				until = until.Add(24*time.Hour - time.Nanosecond)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, BadRequestError{Message: "Invalid UNTIL value: " + value}
+}
+
+func parseRecurrenceDay(value string) (RecurrenceDay, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 {
+		return RecurrenceDay{}, BadRequestError{Message: "Invalid BYDAY value: " + value}
+	}
+
+	weekday, ok := recurrenceWeekdays[value[len(value)-2:]]
+	if !ok {
+		return RecurrenceDay{}, BadRequestError{Message: "Invalid BYDAY value: " + value}
+	}
+
+	day := RecurrenceDay{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RecurrenceDay{}, BadRequestError{Message: "Invalid BYDAY value: " + value}
+		}
+		day.Ordinal = ordinal
+	}
+
+	return day, nil
+}
+
+// Occurrences returns the start times of the rule beginning at start, skipping dates listed in exceptions.
+// As in iCalendar, COUNT is applied before exceptions are removed. The time of day of start is kept.
+func (r *RecurrenceRule) Occurrences(start time.Time, exceptions []time.Time) []time.Time {
+	excluded := make(map[string]bool, len(exceptions))
+	for _, exception := range exceptions {
+		excluded[exception.Format("2006-01-02")] = true
+	}
+
+	var occurrences []time.Time
+	generated := 0
+	done := func(t time.Time) bool {
+		if r.Count > 0 && generated >= r.Count {
+			return true
+		}
+		if r.Until != nil && t.After(*r.Until) {
+			return true
+		}
+		return generated >= MaxRecurrenceOccurrences
+	}
+
+	// Periods without any matching day (such as the 31st in short months) are skipped,
+	// the period limit stops rules that can never match
+	for period := 0; period < MaxRecurrenceOccurrences*12; period++ {
+		candidates := r.periodCandidates(start, period)
+		for _, candidate := range candidates {
+			if candidate.Before(start) {
+				continue
+			}
+			if done(candidate) {
+				return occurrences
+			}
+
+			generated++
+			if !excluded[candidate.Format("2006-01-02")] {
+				occurrences = append(occurrences, candidate)
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// periodCandidates returns the sorted dates of the n-th week or month of the rule
+func (r *RecurrenceRule) periodCandidates(start time.Time, n int) []time.Time {
+	var candidates []time.Time
+
+	if r.Frequency == "WEEKLY" {
+		// Weeks start on Monday
+		offset := (int(start.Weekday()) + 6) % 7
+		weekStart := start.AddDate(0, 0, -offset+7*r.Interval*n)
+
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []RecurrenceDay{{Weekday: start.Weekday()}}
+		}
+		for _, day := range days {
+			candidates = append(candidates, weekStart.AddDate(0, 0, (int(day.Weekday)+6)%7))
+		}
+	} else {
+		monthStart := time.Date(start.Year(), start.Month()+time.Month(r.Interval*n), 1,
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+
+		for _, monthDay := range r.ByMonthDay {
+			if monthDay < 0 {
+				monthDay = daysInMonth + monthDay + 1
+			}
+			if monthDay >= 1 && monthDay <= daysInMonth {
+				candidates = append(candidates, monthStart.AddDate(0, 0, monthDay-1))
+			}
+		}
+
+		for _, day := range r.ByDay {
+			candidates = append(candidates, monthWeekdays(monthStart, daysInMonth, day)...)
+		}
+
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 && start.Day() <= daysInMonth {
+			candidates = append(candidates, monthStart.AddDate(0, 0, start.Day()-1))
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+
+	unique := candidates[:0]
+	for i, candidate := range candidates {
+		if i == 0 || !candidate.Equal(candidates[i-1]) {
+			unique = append(unique, candidate)
+		}
+	}
+	return unique
+}
+
+// monthWeekdays returns the days of a month matching a BYDAY entry
+func monthWeekdays(monthStart time.Time, daysInMonth int, day RecurrenceDay) []time.Time {
+	var matches []time.Time
+	first := (int(day.Weekday) - int(monthStart.Weekday()) + 7) % 7
+	for d := first; d < daysInMonth; d += 7 {
+		matches = append(matches, monthStart.AddDate(0, 0, d))
+	}
+
+	if day.Ordinal == 0 {
+		return matches
+	}
+
+	index := day.Ordinal - 1
+	if day.Ordinal < 0 {
+		index = len(matches) + day.Ordinal
+	}
+	if index < 0 || index >= len(matches) {
+		return nil
+	}
+	return matches[index : index+1]
+}
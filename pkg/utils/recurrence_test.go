@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceRuleErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{"empty", ""},
+		{"missing frequency", "COUNT=3"},
+		{"daily frequency", "FREQ=DAILY;COUNT=3"},
+		{"no end", "FREQ=WEEKLY"},
+		{"count and until", "FREQ=WEEKLY;COUNT=3;UNTIL=20240101"},
+		{"too many occurrences", "FREQ=WEEKLY;COUNT=101"},
+		{"zero interval", "FREQ=WEEKLY;INTERVAL=0;COUNT=3"},
+		{"invalid day", "FREQ=WEEKLY;BYDAY=XX;COUNT=3"},
+		{"numbered day in a weekly rule", "FREQ=WEEKLY;BYDAY=1MO;COUNT=3"},
+		{"month day in a weekly rule", "FREQ=WEEKLY;BYMONTHDAY=1;COUNT=3"},
+		{"invalid month day", "FREQ=MONTHLY;BYMONTHDAY=32;COUNT=3"},
+		{"unsupported part", "FREQ=WEEKLY;BYHOUR=9;COUNT=3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseRecurrenceRule(tt.rule); err == nil {
+				t.Errorf("ParseRecurrenceRule(%q) returned no error", tt.rule)
+			}
+		})
+	}
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	// Monday 8 January 2024 at 18:30
+	start := time.Date(2024, time.January, 8, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		rule       string
+		start      time.Time
+		exceptions []time.Time
+		want       []string
+	}{
+		{
+			name:  "weekly on the start day",
+			rule:  "RRULE:FREQ=WEEKLY;COUNT=3",
+			start: start,
+			want:  []string{"2024-01-08", "2024-01-15", "2024-01-22"},
+		},
+		{
+			name:  "weekly on several days",
+			rule:  "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4",
+			start: start,
+			want:  []string{"2024-01-09", "2024-01-11", "2024-01-16", "2024-01-18"},
+		},
+		{
+			name:  "every other week until a date",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;UNTIL=20240205",
+			start: start,
+			want:  []string{"2024-01-08", "2024-01-22", "2024-02-05"},
+		},
+		{
+			name:       "exceptions count towards COUNT",
+			rule:       "FREQ=WEEKLY;COUNT=3",
+			start:      start,
+			exceptions: []time.Time{time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)},
+			want:       []string{"2024-01-08", "2024-01-22"},
+		},
+		{
+			name:  "monthly on the start day skips short months",
+			rule:  "FREQ=MONTHLY;COUNT=3",
+			start: time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC),
+			want:  []string{"2024-01-31", "2024-03-31", "2024-05-31"},
+		},
+		{
+			name:  "monthly on the last day",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			start: start,
+			want:  []string{"2024-01-31", "2024-02-29", "2024-03-31"},
+		},
+		{
+			name:  "monthly on the first Monday",
+			rule:  "FREQ=MONTHLY;BYDAY=1MO;COUNT=3",
+			start: start,
+			want:  []string{"2024-02-05", "2024-03-04", "2024-04-01"},
+		},
+		{
+			name:  "monthly on the last Friday",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR;COUNT=2",
+			start: start,
+			want:  []string{"2024-01-26", "2024-02-23"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule(%q) returned %v", tt.rule, err)
+			}
+
+			occurrences := rule.Occurrences(tt.start, tt.exceptions)
+			if len(occurrences) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", occurrences, tt.want)
+			}
+			for i, occurrence := range occurrences {
+				if occurrence.Format("2006-01-02") != tt.want[i] {
+					t.Errorf("Occurrences()[%d] = %v, want %s", i, occurrence, tt.want[i])
+				}
+				if occurrence.Hour() != tt.start.Hour() || occurrence.Minute() != tt.start.Minute() {
+					t.Errorf("Occurrences()[%d] = %v, want the time of day of %v", i, occurrence, tt.start)
+				}
+			}
+		})
+	}
+}
@@ -121,14 +121,8 @@ func CloseRedis() {
 		return
 	}
 	
-	// Use context with timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	
-	// Flush any pending commands
-	if err := Rdb.FlushDB(ctx).Err(); err != nil {
-		log.Printf("Warning: Failed to flush Redis DB: %v", err)
-	}
+	// The database is not flushed, it is shared with the other replicas and
+	// revoked tokens and buffered news views must outlive restarts
 	
 	// Close the connection
 	err := Rdb.Close()
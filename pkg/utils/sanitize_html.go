@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"golang.org/x/net/html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// sanitizeAllowedTags lists the elements kept by SanitizeHTML with the attributes each may carry
+var sanitizeAllowedTags = map[string][]string{
+	"p": {}, "br": {}, "hr": {}, "div": {}, "span": {},
+	"h1": {}, "h2": {}, "h3": {}, "h4": {}, "h5": {}, "h6": {},
+	"strong": {}, "b": {}, "em": {}, "i": {}, "u": {}, "s": {}, "del": {}, "sub": {}, "sup": {}, "mark": {},
+	"blockquote": {}, "pre": {}, "code": {"class"},
+	"ul": {}, "ol": {"start"}, "li": {},
+	"a":     {"href", "title"},
+	"img":   {"src", "alt", "title", "width", "height"},
+	"table": {}, "thead": {}, "tbody": {}, "tr": {}, "th": {"colspan", "rowspan"}, "td": {"colspan", "rowspan"},
+	"figure": {}, "figcaption": {},
+}
+
+// sanitizeDroppedContent lists the elements removed along with everything inside them
+var sanitizeDroppedContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true, "noscript": true,
+	"template": true, "svg": true, "math": true, "textarea": true, "select": true, "head": true, "title": true,
+}
+
+var sanitizeVoidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+// isSafeURL accepts http, https and mailto URLs as well as relative ones
+func isSafeURL(url string) bool {
+	lower := strings.ToLower(strings.TrimSpace(url))
+	colon := strings.IndexByte(lower, ':')
+	if colon < 0 || strings.ContainsAny(lower[:colon], "/?#") {
+		return true
+	}
+	scheme := lower[:colon]
+	return scheme == "http" || scheme == "https" || scheme == "mailto"
+}
+
+// SanitizeHTML keeps the elements and attributes of an allowlist and drops everything else,
+// scripts and styles with their content. Links get rel="nofollow noopener noreferrer" and
+// the output is always well formed.
+func SanitizeHTML(input string) string {
+	var out strings.Builder
+	var open []string
+	dropped := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.TextToken:
+			if dropped == 0 {
+				out.WriteString(html.EscapeString(token.Data))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if sanitizeDroppedContent[token.Data] {
+				if tokenType == html.StartTagToken {
+					dropped++
+				}
+				continue
+			}
+			attributes, allowed := sanitizeAllowedTags[token.Data]
+			if !allowed || dropped > 0 {
+				continue
+			}
+
+			out.WriteString("<" + token.Data)
+			for _, attribute := range token.Attr {
+				if !sanitizeAllowedAttribute(token.Data, attribute, attributes) {
+					continue
+				}
+				out.WriteString(" " + attribute.Key + `="` + html.EscapeString(attribute.Val) + `"`)
+			}
+			if token.Data == "a" {
+				out.WriteString(` rel="nofollow noopener noreferrer"`)
+			}
+			out.WriteString(">")
+
+			if !sanitizeVoidTags[token.Data] {
+				open = append(open, token.Data)
+			}
+
+		case html.EndTagToken:
+			if sanitizeDroppedContent[token.Data] {
+				if dropped > 0 {
+					dropped--
+				}
+				continue
+			}
+			// Close the element and any element left open inside it, stray end tags are ignored
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						out.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		out.WriteString("</" + open[i] + ">")
+	}
+	return out.String()
+}
+
+func sanitizeAllowedAttribute(tag string, attribute html.Attribute, allowed []string) bool {
+	if attribute.Namespace != "" {
+		return false
+	}
+
+	found := false
+	for _, name := range allowed {
+		if name == attribute.Key {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	switch attribute.Key {
+	case "href", "src":
+		return isSafeURL(attribute.Val)
+	case "class":
+		// Only the language of code blocks is kept
+		return tag == "code" && strings.HasPrefix(attribute.Val, "language-") && !strings.ContainsAny(attribute.Val, " \t\"'<>")
+	case "start", "width", "height", "colspan", "rowspan":
+		return attribute.Val != "" && strings.IndexFunc(attribute.Val, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+	}
+	return true
+}
+
+// HTMLToText returns the text of an HTML fragment with its whitespace collapsed
+func HTMLToText(input string) string {
+	var text strings.Builder
+	dropped := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+
+		token := tokenizer.Token()
+		switch tokenType {
+		case html.TextToken:
+			if dropped == 0 {
+				text.WriteString(token.Data)
+			}
+		case html.StartTagToken:
+			if sanitizeDroppedContent[token.Data] {
+				dropped++
+			}
+			text.WriteString(" ")
+		case html.EndTagToken:
+			if sanitizeDroppedContent[token.Data] && dropped > 0 {
+				dropped--
+			}
+			text.WriteString(" ")
+		case html.SelfClosingTagToken:
+			text.WriteString(" ")
+		}
+	}
+
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+// Excerpt shortens a text to at most maxLength characters, cutting between words
+func Excerpt(text string, maxLength int) string {
+	if utf8.RuneCountInString(text) <= maxLength {
+		return text
+	}
+
+	runes := []rune(text)[:maxLength]
+	cut := string(runes)
+	if space := strings.LastIndexFunc(cut, unicode.IsSpace); space > 0 {
+		cut = cut[:space]
+	}
+	return strings.TrimRightFunc(cut, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+// ReadingTime estimates the minutes needed to read a text at wordsPerMinute, at least one for any text
+func ReadingTime(text string, wordsPerMinute int) int {
+	words := len(strings.Fields(text))
+	if words == 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
@@ -0,0 +1,75 @@
+package utils
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "allowed markup is kept",
+			input: "<p>Hello <strong>world</strong></p>",
+			want:  "<p>Hello <strong>world</strong></p>",
+		},
+		{
+			name:  "scripts are dropped with their content",
+			input: "<p>a</p><script>alert(1)</script><p>b</p>",
+			want:  "<p>a</p><p>b</p>",
+		},
+		{
+			name:  "unknown elements are unwrapped",
+			input: "<section><p>a</p></section>",
+			want:  "<p>a</p>",
+		},
+		{
+			name:  "event handlers are removed",
+			input: `<img src="/a.png" onerror="alert(1)">`,
+			want:  `<img src="/a.png">`,
+		},
+		{
+			name:  "javascript links lose their href",
+			input: `<a href="javascript:alert(1)">x</a>`,
+			want:  `<a rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name:  "links get rel",
+			input: `<a href="https://example.com" target="_blank">x</a>`,
+			want:  `<a href="https://example.com" rel="nofollow noopener noreferrer">x</a>`,
+		},
+		{
+			name:  "only code languages are kept as classes",
+			input: `<pre class="x"><code class="language-go">x</code></pre>`,
+			want:  `<pre><code class="language-go">x</code></pre>`,
+		},
+		{
+			name:  "numeric attributes must be numbers",
+			input: `<ol start="3"><li>a</li></ol><td colspan="x">b</td>`,
+			want:  `<ol start="3"><li>a</li></ol><td>b</td>`,
+		},
+		{
+			name:  "unclosed elements are closed",
+			input: "<p><em>a",
+			want:  "<p><em>a</em></p>",
+		},
+		{
+			name:  "stray end tags are ignored",
+			input: "a</p></div>",
+			want:  "a",
+		},
+		{
+			name:  "text is escaped",
+			input: "1 &lt; 2 &amp; 3",
+			want:  "1 &lt; 2 &amp; 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}